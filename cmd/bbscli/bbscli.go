@@ -256,6 +256,13 @@ func main() {
 				},
 			},
 		},
+		{
+			Name:  "outbox",
+			Usage: "lists submissions to remote boards that are kept to be retried, and their status",
+			Action: func(ctx *cli.Context) error {
+				return call(rpc.GetOutbox())
+			},
+		},
 		{
 			Name:  "content",
 			Usage: "manages boards and their content",
@@ -280,13 +287,18 @@ func main() {
 							Name:  "seed, s",
 							Usage: "seed to generate key pair of the board",
 						},
+						cli.IntFlag{
+							Name:  "pow",
+							Usage: "(optional) proof-of-work difficulty (leading zero bits) required of submissions",
+						},
 					},
 					Action: func(ctx *cli.Context) error {
 						return call(rpc.NewBoard(&store.NewBoardIn{
-							Name: ctx.String("name"),
-							Body: ctx.String("body"),
-							TS:   ctx.Int64("timestamp"),
-							Seed: ctx.String("seed"),
+							Name:    ctx.String("name"),
+							Body:    ctx.String("body"),
+							TS:      ctx.Int64("timestamp"),
+							Seed:    ctx.String("seed"),
+							PoWBits: ctx.Int("pow"),
 						}))
 					},
 				},
@@ -305,6 +317,96 @@ func main() {
 						}))
 					},
 				},
+				{
+					Name:  "publish_board_deletion",
+					Usage: "publishes the deletion of a master board to it's subscribers",
+					Flags: cli.FlagsByName{
+						cli.StringFlag{
+							Name:  "public-key, pk",
+							Usage: "public key of the master board to mark as deleted",
+						},
+						cli.StringFlag{
+							Name:  "reason",
+							Usage: "optional reason of deletion",
+						},
+						cli.StringFlag{
+							Name:  "successor",
+							Usage: "optional public key of the board that replaces the deleted board",
+						},
+					},
+					Action: func(ctx *cli.Context) error {
+						return call(rpc.PublishBoardDeletion(&store.BoardDeletionIn{
+							BoardPubKeyStr: ctx.String("public-key"),
+							Reason:         ctx.String("reason"),
+							SuccessorStr:   ctx.String("successor"),
+						}))
+					},
+				},
+				{
+					Name:  "set_board_pow",
+					Usage: "sets the proof-of-work difficulty that a master board requires of submissions",
+					Flags: cli.FlagsByName{
+						cli.StringFlag{
+							Name:  "public-key, pk",
+							Usage: "public key of the master board",
+						},
+						cli.StringFlag{
+							Name:  "bits",
+							Usage: "number of leading zero bits required of submission hashes, 0 to disable",
+						},
+					},
+					Action: func(ctx *cli.Context) error {
+						return call(rpc.SetBoardPoW(&store.BoardPoWIn{
+							BoardPubKeyStr: ctx.String("public-key"),
+							PoWBitsStr:     ctx.String("bits"),
+						}))
+					},
+				},
+				{
+					Name:  "transfer_board",
+					Usage: "migrates a master board to a new keypair, carrying over it's content and subscribers",
+					Flags: cli.FlagsByName{
+						cli.StringFlag{
+							Name:  "public-key, pk",
+							Usage: "public key of the master board to transfer",
+						},
+						cli.StringFlag{
+							Name:  "seed",
+							Usage: "seed of the new keypair",
+						},
+					},
+					Action: func(ctx *cli.Context) error {
+						return call(rpc.TransferBoard(&store.TransferBoardIn{
+							BoardPubKeyStr: ctx.String("public-key"),
+							Seed:           ctx.String("seed"),
+						}))
+					},
+				},
+				{
+					Name:  "fork_board",
+					Usage: "forks a remote board into a new master board, replaying it's signed content",
+					Flags: cli.FlagsByName{
+						cli.StringFlag{
+							Name:  "public-key, pk",
+							Usage: "public key of the remote board to fork",
+						},
+						cli.StringFlag{
+							Name:  "seed",
+							Usage: "seed of the fork's keypair",
+						},
+						cli.StringFlag{
+							Name:  "name",
+							Usage: "optional name of the fork (defaults to name of forked board)",
+						},
+					},
+					Action: func(ctx *cli.Context) error {
+						return call(rpc.ForkBoard(&store.ForkBoardIn{
+							BoardPubKeyStr: ctx.String("public-key"),
+							Seed:           ctx.String("seed"),
+							Name:           ctx.String("name"),
+						}))
+					},
+				},
 				{
 					Name:  "export_board",
 					Usage: "exports a board",
@@ -370,10 +472,69 @@ func main() {
 							Name:  "board-public-key, bpk",
 							Usage: "public key of the board to obtain",
 						},
+						cli.StringFlag{
+							Name:  "sort-mode, sm",
+							Usage: "(optional) order of threads (bumped, hot, top, new, reputation), leave blank for order of creation",
+						},
+						cli.StringFlag{
+							Name:  "perspective, u",
+							Usage: "(optional) public key of the user to view from",
+						},
+						cli.StringFlag{
+							Name:  "filter-mode, fm",
+							Usage: "(optional) how to show content of users blocked or marked as spam by perspective (hide, collapse)",
+						},
+						cli.StringFlag{
+							Name:  "trust-depth, td",
+							Usage: "(optional) number of trust relations to follow from perspective when filtering",
+						},
+						cli.StringFlag{
+							Name:  "min-reputation, mr",
+							Usage: "(optional) hide content of users with reputation lower than this",
+						},
 					},
 					Action: func(ctx *cli.Context) error {
 						return call(rpc.GetBoardPage(&store.BoardIn{
-							PubKeyStr: ctx.String("board-public-key"),
+							PubKeyStr:     ctx.String("board-public-key"),
+							UserPubKeyStr: ctx.String("perspective"),
+							SortModeStr:   ctx.String("sort-mode"),
+							FilterIn: store.FilterIn{
+								FilterModeStr:    ctx.String("filter-mode"),
+								TrustDepthStr:    ctx.String("trust-depth"),
+								MinReputationStr: ctx.String("min-reputation"),
+							},
+						}))
+					},
+				},
+				{
+					Name:  "get_participants",
+					Usage: "gets the participating users of a board and their reputation",
+					Flags: cli.FlagsByName{
+						cli.StringFlag{
+							Name:  "board-public-key, bpk",
+							Usage: "public key of the board",
+						},
+						cli.StringFlag{
+							Name:  "sort-mode, sm",
+							Usage: "(optional) order of users (reputation), leave blank for order of participation",
+						},
+						cli.StringFlag{
+							Name:  "perspective, u",
+							Usage: "(optional) public key of the user to compute reputation from",
+						},
+						cli.StringFlag{
+							Name:  "min-reputation, mr",
+							Usage: "(optional) hide users with reputation lower than this",
+						},
+					},
+					Action: func(ctx *cli.Context) error {
+						return call(rpc.GetParticipants(&store.BoardIn{
+							PubKeyStr:     ctx.String("board-public-key"),
+							UserPubKeyStr: ctx.String("perspective"),
+							SortModeStr:   ctx.String("sort-mode"),
+							FilterIn: store.FilterIn{
+								MinReputationStr: ctx.String("min-reputation"),
+							},
 						}))
 					},
 				},
@@ -389,11 +550,242 @@ func main() {
 							Name:  "thread-hash, th",
 							Usage: "the hash of the thread in which to obtain thread page",
 						},
+						cli.StringFlag{
+							Name:  "perspective, u",
+							Usage: "(optional) public key of the user to view from",
+						},
+						cli.StringFlag{
+							Name:  "filter-mode, fm",
+							Usage: "(optional) how to show content of users blocked or marked as spam by perspective (hide, collapse)",
+						},
+						cli.StringFlag{
+							Name:  "trust-depth, td",
+							Usage: "(optional) number of trust relations to follow from perspective when filtering",
+						},
+						cli.StringFlag{
+							Name:  "min-reputation, mr",
+							Usage: "(optional) hide content of users with reputation lower than this",
+						},
 					},
 					Action: func(ctx *cli.Context) error {
 						return call(rpc.GetThreadPage(&store.ThreadIn{
 							BoardPubKeyStr: ctx.String("board-public-key"),
 							ThreadRefStr:   ctx.String("thread-hash"),
+							UserPubKeyStr:  ctx.String("perspective"),
+							FilterIn: store.FilterIn{
+								FilterModeStr:    ctx.String("filter-mode"),
+								TrustDepthStr:    ctx.String("trust-depth"),
+								MinReputationStr: ctx.String("min-reputation"),
+							},
+						}))
+					},
+				},
+				{
+					Name:  "get_board_history",
+					Usage: "lists the root sequences of a board that are kept locally, with timestamps",
+					Flags: cli.FlagsByName{
+						cli.StringFlag{
+							Name:  "board-public-key, bpk",
+							Usage: "public key of the board",
+						},
+					},
+					Action: func(ctx *cli.Context) error {
+						return call(rpc.GetBoardHistory(&store.BoardHistoryIn{
+							BoardPubKeyStr: ctx.String("board-public-key"),
+						}))
+					},
+				},
+				{
+					Name:  "get_board_page_at",
+					Usage: "gets a view of a board and it's threads as of a past root sequence",
+					Flags: cli.FlagsByName{
+						cli.StringFlag{
+							Name:  "board-public-key, bpk",
+							Usage: "public key of the board to obtain",
+						},
+						cli.StringFlag{
+							Name:  "seq, s",
+							Usage: "root sequence of the board to view",
+						},
+						cli.StringFlag{
+							Name:  "sort-mode, sm",
+							Usage: "(optional) order of threads (bumped, hot, top, new, reputation), leave blank for order of creation",
+						},
+						cli.StringFlag{
+							Name:  "perspective, u",
+							Usage: "(optional) public key of the user to view from",
+						},
+						cli.StringFlag{
+							Name:  "filter-mode, fm",
+							Usage: "(optional) how to show content of users blocked or marked as spam by perspective (hide, collapse)",
+						},
+						cli.StringFlag{
+							Name:  "trust-depth, td",
+							Usage: "(optional) number of trust relations to follow from perspective when filtering",
+						},
+						cli.StringFlag{
+							Name:  "min-reputation, mr",
+							Usage: "(optional) hide content of users with reputation lower than this",
+						},
+					},
+					Action: func(ctx *cli.Context) error {
+						return call(rpc.GetBoardPageAt(&store.BoardAtIn{
+							BoardIn: store.BoardIn{
+								PubKeyStr:     ctx.String("board-public-key"),
+								UserPubKeyStr: ctx.String("perspective"),
+								SortModeStr:   ctx.String("sort-mode"),
+								FilterIn: store.FilterIn{
+									FilterModeStr:    ctx.String("filter-mode"),
+									TrustDepthStr:    ctx.String("trust-depth"),
+									MinReputationStr: ctx.String("min-reputation"),
+								},
+							},
+							SeqStr: ctx.String("seq"),
+						}))
+					},
+				},
+				{
+					Name:  "get_thread_page_at",
+					Usage: "gets a view of a board's thread and it's posts as of a past root sequence",
+					Flags: cli.FlagsByName{
+						cli.StringFlag{
+							Name:  "board-public-key, bpk",
+							Usage: "the public key of the board in which the thread resides",
+						},
+						cli.StringFlag{
+							Name:  "thread-hash, th",
+							Usage: "the hash of the thread in which to obtain thread page",
+						},
+						cli.StringFlag{
+							Name:  "seq, s",
+							Usage: "root sequence of the board to view",
+						},
+						cli.StringFlag{
+							Name:  "perspective, u",
+							Usage: "(optional) public key of the user to view from",
+						},
+						cli.StringFlag{
+							Name:  "filter-mode, fm",
+							Usage: "(optional) how to show content of users blocked or marked as spam by perspective (hide, collapse)",
+						},
+						cli.StringFlag{
+							Name:  "trust-depth, td",
+							Usage: "(optional) number of trust relations to follow from perspective when filtering",
+						},
+						cli.StringFlag{
+							Name:  "min-reputation, mr",
+							Usage: "(optional) hide content of users with reputation lower than this",
+						},
+					},
+					Action: func(ctx *cli.Context) error {
+						return call(rpc.GetThreadPageAt(&store.ThreadAtIn{
+							ThreadIn: store.ThreadIn{
+								BoardPubKeyStr: ctx.String("board-public-key"),
+								ThreadRefStr:   ctx.String("thread-hash"),
+								UserPubKeyStr:  ctx.String("perspective"),
+								FilterIn: store.FilterIn{
+									FilterModeStr:    ctx.String("filter-mode"),
+									TrustDepthStr:    ctx.String("trust-depth"),
+									MinReputationStr: ctx.String("min-reputation"),
+								},
+							},
+							SeqStr: ctx.String("seq"),
+						}))
+					},
+				},
+				{
+					Name:  "diff_board",
+					Usage: "gets the threads, posts, votes and board metadata changed between two root sequences of a board",
+					Flags: cli.FlagsByName{
+						cli.StringFlag{
+							Name:  "board-public-key, bpk",
+							Usage: "public key of the board",
+						},
+						cli.StringFlag{
+							Name:  "from-seq, f",
+							Usage: "root sequence to compare from",
+						},
+						cli.StringFlag{
+							Name:  "to-seq, t",
+							Usage: "root sequence to compare to",
+						},
+					},
+					Action: func(ctx *cli.Context) error {
+						return call(rpc.DiffBoard(&store.DiffBoardIn{
+							BoardPubKeyStr: ctx.String("board-public-key"),
+							FromSeqStr:     ctx.String("from-seq"),
+							ToSeqStr:       ctx.String("to-seq"),
+						}))
+					},
+				},
+				{
+					Name:  "get_thread_tree",
+					Usage: "gets a view of a board's thread and it's posts as a tree of replies",
+					Flags: cli.FlagsByName{
+						cli.StringFlag{
+							Name:  "board-public-key, bpk",
+							Usage: "the public key of the board in which the thread resides",
+						},
+						cli.StringFlag{
+							Name:  "thread-hash, th",
+							Usage: "the hash of the thread in which to obtain thread tree",
+						},
+						cli.StringFlag{
+							Name:  "root-hash, rh",
+							Usage: "(optional) the hash of the post in which to start the tree from",
+						},
+						cli.StringFlag{
+							Name:  "max-depth, md",
+							Usage: "(optional) number of reply levels to include",
+						},
+						cli.StringFlag{
+							Name:  "collapsed, c",
+							Usage: "(optional) comma separated hashes of posts in which to exclude replies",
+						},
+						cli.StringFlag{
+							Name:  "start-index, si",
+							Usage: "(optional) index of first reply of root to include",
+						},
+						cli.StringFlag{
+							Name:  "page-size, ps",
+							Usage: "(optional) max number of replies to include per post",
+						},
+						cli.StringFlag{
+							Name:  "perspective, u",
+							Usage: "(optional) public key of the user to view from",
+						},
+						cli.StringFlag{
+							Name:  "filter-mode, fm",
+							Usage: "(optional) how to show content of users blocked or marked as spam by perspective (hide, collapse)",
+						},
+						cli.StringFlag{
+							Name:  "trust-depth, td",
+							Usage: "(optional) number of trust relations to follow from perspective when filtering",
+						},
+						cli.StringFlag{
+							Name:  "min-reputation, mr",
+							Usage: "(optional) hide content of users with reputation lower than this",
+						},
+					},
+					Action: func(ctx *cli.Context) error {
+						return call(rpc.GetThreadTree(&store.ThreadTreeIn{
+							ThreadIn: store.ThreadIn{
+								BoardPubKeyStr: ctx.String("board-public-key"),
+								ThreadRefStr:   ctx.String("thread-hash"),
+								UserPubKeyStr:  ctx.String("perspective"),
+								FilterIn: store.FilterIn{
+									FilterModeStr:    ctx.String("filter-mode"),
+									TrustDepthStr:    ctx.String("trust-depth"),
+									MinReputationStr: ctx.String("min-reputation"),
+								},
+							},
+							RootHashStr:  ctx.String("root-hash"),
+							MaxDepthStr:  ctx.String("max-depth"),
+							CollapsedStr: ctx.String("collapsed"),
+							PaginatedIn: store.PaginatedIn{
+								StartIndexStr: ctx.String("start-index"),
+								PageSizeStr:   ctx.String("page-size"),
+							},
 						}))
 					},
 				},
@@ -409,11 +801,209 @@ func main() {
 							Name:  "user-public-key, upk",
 							Usage: "public key of user to get follow page of",
 						},
+						cli.StringFlag{
+							Name:  "perspective, u",
+							Usage: "(optional) public key of the user to compute reputation from",
+						},
 					},
 					Action: func(ctx *cli.Context) error {
 						return call(rpc.GetFollowPage(&store.UserIn{
 							BoardPubKeyStr: ctx.String("board-public-key"),
 							UserPubKeyStr:  ctx.String("user-public-key"),
+							PerspectiveStr: ctx.String("perspective"),
+						}))
+					},
+				},
+				{
+					Name:  "get_user_submissions",
+					Usage: "gets the threads, posts and votes submitted by a user to a board",
+					Flags: cli.FlagsByName{
+						cli.StringFlag{
+							Name:  "board-public-key, bpk",
+							Usage: "public key of board in which to obtain submissions",
+						},
+						cli.StringFlag{
+							Name:  "user-public-key, upk",
+							Usage: "public key of user to get submissions of",
+						},
+						cli.StringFlag{
+							Name:  "types, t",
+							Usage: "(optional) comma separated types of submissions to include (thread, post, thread_vote, post_vote, user_vote)",
+						},
+						cli.BoolFlag{
+							Name:  "reverse, r",
+							Usage: "(optional) whether to obtain the most recent submissions first",
+						},
+						cli.StringFlag{
+							Name:  "start-index, si",
+							Usage: "(optional) index of first submission to include",
+						},
+						cli.StringFlag{
+							Name:  "page-size, ps",
+							Usage: "(optional) max number of submissions to include",
+						},
+					},
+					Action: func(ctx *cli.Context) error {
+						return call(rpc.GetUserSubmissions(&store.UserSubmissionsIn{
+							BoardPubKeyStr: ctx.String("board-public-key"),
+							UserPubKeyStr:  ctx.String("user-public-key"),
+							TypesStr:       ctx.String("types"),
+							ReverseStr:     strconv.FormatBool(ctx.Bool("reverse")),
+							PaginatedIn: store.PaginatedIn{
+								StartIndexStr: ctx.String("start-index"),
+								PageSizeStr:   ctx.String("page-size"),
+							},
+						}))
+					},
+				},
+				{
+					Name:  "vote_audit",
+					Usage: "gets the signed votes behind the vote tally of a thread, post or user",
+					Flags: cli.FlagsByName{
+						cli.StringFlag{
+							Name:  "board-public-key, bpk",
+							Usage: "public key of board in which the votes reside",
+						},
+						cli.StringFlag{
+							Name:  "ref, r",
+							Usage: "hash of the thread or post, or public key of the user",
+						},
+						cli.StringFlag{
+							Name:  "start-index, si",
+							Usage: "(optional) index of first vote to include",
+						},
+						cli.StringFlag{
+							Name:  "page-size, ps",
+							Usage: "(optional) max number of votes to include",
+						},
+					},
+					Action: func(ctx *cli.Context) error {
+						return call(rpc.GetVoteAudit(&store.VoteAuditIn{
+							BoardPubKeyStr: ctx.String("board-public-key"),
+							RefStr:         ctx.String("ref"),
+							PaginatedIn: store.PaginatedIn{
+								StartIndexStr: ctx.String("start-index"),
+								PageSizeStr:   ctx.String("page-size"),
+							},
+						}))
+					},
+				},
+				{
+					Name:  "quarantine",
+					Usage: "gets received content of a board that failed signature verification",
+					Flags: cli.FlagsByName{
+						cli.StringFlag{
+							Name:  "board-public-key, bpk",
+							Usage: "public key of the board",
+						},
+						cli.StringFlag{
+							Name:  "start-index, si",
+							Usage: "(optional) index of first quarantined content to include",
+						},
+						cli.StringFlag{
+							Name:  "page-size, ps",
+							Usage: "(optional) max number of quarantined content to include",
+						},
+					},
+					Action: func(ctx *cli.Context) error {
+						return call(rpc.GetQuarantine(&store.QuarantineIn{
+							BoardPubKeyStr: ctx.String("board-public-key"),
+							PaginatedIn: store.PaginatedIn{
+								StartIndexStr: ctx.String("start-index"),
+								PageSizeStr:   ctx.String("page-size"),
+							},
+						}))
+					},
+				},
+				{
+					Name:  "board_stats",
+					Usage: "gets statistics of a board",
+					Flags: cli.FlagsByName{
+						cli.StringFlag{
+							Name:  "board-public-key, bpk",
+							Usage: "public key of the board to obtain statistics of",
+						},
+						cli.StringFlag{
+							Name:  "days, d",
+							Usage: "(optional) number of days to count posts per day of",
+						},
+						cli.StringFlag{
+							Name:  "top-count, tc",
+							Usage: "(optional) max number of top contributors to obtain",
+						},
+					},
+					Action: func(ctx *cli.Context) error {
+						return call(rpc.GetBoardStats(&store.BoardStatsIn{
+							BoardPubKeyStr: ctx.String("board-public-key"),
+							DaysStr:        ctx.String("days"),
+							TopCountStr:    ctx.String("top-count"),
+						}))
+					},
+				},
+				{
+					Name:  "search",
+					Usage: "searches threads and posts of subscribed boards",
+					Flags: cli.FlagsByName{
+						cli.StringFlag{
+							Name:  "query, q",
+							Usage: "terms to search for",
+						},
+						cli.StringFlag{
+							Name:  "board-public-key, bpk",
+							Usage: "(optional) public key of the board to search, leave blank to search all boards",
+						},
+						cli.StringFlag{
+							Name:  "start-index, si",
+							Usage: "(optional) index of the first result to obtain",
+						},
+						cli.StringFlag{
+							Name:  "page-size, ps",
+							Usage: "(optional) max number of results to obtain",
+						},
+					},
+					Action: func(ctx *cli.Context) error {
+						return call(rpc.Search(&store.SearchIn{
+							BoardPubKeyStr: ctx.String("board-public-key"),
+							Query:          ctx.String("query"),
+							PaginatedIn: store.PaginatedIn{
+								StartIndexStr: ctx.String("start-index"),
+								PageSizeStr:   ctx.String("page-size"),
+							},
+						}))
+					},
+				},
+				{
+					Name:  "get_feed",
+					Usage: "gets the most recent threads and posts of subscribed boards",
+					Flags: cli.FlagsByName{
+						cli.StringFlag{
+							Name:  "boards, b",
+							Usage: "(optional) comma separated public keys of boards to include, leave blank to include all boards",
+						},
+						cli.StringFlag{
+							Name:  "types, t",
+							Usage: "(optional) comma separated types of content to include (thread, post)",
+						},
+						cli.StringFlag{
+							Name:  "authors, a",
+							Usage: "(optional) comma separated public keys of creators to include",
+						},
+						cli.StringFlag{
+							Name:  "cursor, c",
+							Usage: "(optional) cursor obtained from previous page",
+						},
+						cli.StringFlag{
+							Name:  "page-size, ps",
+							Usage: "(optional) max number of items to obtain",
+						},
+					},
+					Action: func(ctx *cli.Context) error {
+						return call(rpc.GetFeed(&store.FeedIn{
+							BoardsStr:   ctx.String("boards"),
+							TypesStr:    ctx.String("types"),
+							AuthorsStr:  ctx.String("authors"),
+							CursorStr:   ctx.String("cursor"),
+							PageSizeStr: ctx.String("page-size"),
 						}))
 					},
 				},
@@ -30,10 +30,8 @@ const (
 	defaultWebPort                         = 8080
 	defaultMedialGarbageCollectionInterval = time.Minute
 	defaultMedialItemTimeout               = time.Minute * 3
-)
-
-var (
-	compilerInternal = 1
+	defaultSeqWaitTimeout                  = 30
+	defaultPublishInterval                 = 1
 )
 
 // Config represents configuration for node.
@@ -48,6 +46,11 @@ type Config struct {
 	CXORPCPort                 int             `json:"cxo-rpc-port,omitempty"`       // Listening RPC port of CXO.
 	EnforcedMessengerAddresses cli.StringSlice `json:"enforced-messenger-addresses"` // Addresses of messenger servers to enforce.
 	EnforcedSubscriptions      cli.StringSlice `json:"enforced-subscriptions"`       // Subscriptions to enforce.
+	PublishInterval            int             `json:"publish-interval"`             // Seconds between publishing changes of master boards.
+	PublishMaxBatch            int             `json:"publish-max-batch"`            // Max changes per published root (0 for unlimited).
+	PublishWhenIdle            bool            `json:"publish-when-idle"`            // Whether to publish immediately when board is idle.
+	SeqWaitTimeout             int             `json:"seq-wait-timeout"`             // Seconds to wait for submissions to be published.
+	RemoveDeletedBoards        bool            `json:"remove-deleted-boards"`        // Whether to remove remote boards deleted by their masters.
 	WebPort                    int             `json:"web-port"`                     // Port to serve HTTP API/GUI.
 	WebGUI                     bool            `json:"web-gui"`                      // Whether to enable GUI.
 	WebGUIDir                  string          `json:"web-gui-dir,omitempty"`        // Full path of GUI static files.
@@ -70,6 +73,11 @@ func NewDefaultConfig() *Config {
 		CXORPCPort:                 defaultCXORPCPort,
 		EnforcedMessengerAddresses: []string{},
 		EnforcedSubscriptions:      []string{},
+		PublishInterval:            defaultPublishInterval,
+		PublishMaxBatch:            0,
+		PublishWhenIdle:            false,
+		SeqWaitTimeout:             defaultSeqWaitTimeout,
+		RemoveDeletedBoards:        false,
 		WebPort:                    defaultWebPort,
 		WebGUI:                     true,
 		WebGUIDir:                  defaultStaticSubDir, // --> Action: set as '$HOME/.skybbs/static/dist'
@@ -84,6 +92,12 @@ func (c *Config) Print() {
 
 // PostProcess checks the flags and processes them.
 func (c *Config) PostProcess() error {
+	if c.PublishInterval < 1 {
+		return fmt.Errorf("invalid publish interval %d, must be at least 1 second", c.PublishInterval)
+	}
+	if c.PublishMaxBatch < 0 {
+		return fmt.Errorf("invalid publish max batch %d, must be at least 0 (0 for unlimited)", c.PublishMaxBatch)
+	}
 	if !c.Memory {
 		if c.ConfigDir == "" {
 			c.ConfigDir = filepath.Join(file.UserHome(), defaultConfigSubDir)
@@ -129,7 +143,11 @@ func (c *Config) GenerateAction() cli.ActionFunc {
 							CXORPCPort:                 &c.CXORPCPort,
 						},
 						&state.CompilerConfig{
-							UpdateInterval: &compilerInternal,
+							UpdateInterval:  &c.PublishInterval,
+							MaxBatchSize:    &c.PublishMaxBatch,
+							PublishWhenIdle: &c.PublishWhenIdle,
+							WaitTimeout:     &c.SeqWaitTimeout,
+							RemoveDeleted:   &c.RemoveDeletedBoards,
 						},
 					),
 					Medial: medial.NewServer(&medial.ServerConfig{
@@ -247,6 +265,34 @@ func main() {
 			Value: &config.EnforcedSubscriptions,
 			Usage: "list of public keys of boards to enforce subscriptions with",
 		},
+		cli.IntFlag{
+			Name:        "publish-interval",
+			Destination: &config.PublishInterval,
+			Value:       config.PublishInterval,
+			Usage:       "seconds between publishing changes of master boards",
+		},
+		cli.IntFlag{
+			Name:        "publish-max-batch",
+			Destination: &config.PublishMaxBatch,
+			Value:       config.PublishMaxBatch,
+			Usage:       "max number of changes to batch into a published root before publishing immediately (0 for unlimited)",
+		},
+		cli.BoolFlag{
+			Name:        "publish-when-idle",
+			Destination: &config.PublishWhenIdle,
+			Usage:       "whether to publish changes immediately when nothing was published within the publish interval",
+		},
+		cli.IntFlag{
+			Name:        "seq-wait-timeout",
+			Destination: &config.SeqWaitTimeout,
+			Value:       config.SeqWaitTimeout,
+			Usage:       "seconds to wait for a submission to be published before giving up",
+		},
+		cli.BoolFlag{
+			Name:        "remove-deleted-boards",
+			Destination: &config.RemoveDeletedBoards,
+			Usage:       "whether to remove local data of remote boards that are deleted by their masters",
+		},
 		cli.IntFlag{
 			Name:        "web-port",
 			Destination: &config.WebPort,
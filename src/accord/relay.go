@@ -141,14 +141,19 @@ func (r *Relay) processSubmission(conn *factory.Connection, address string, pk c
 		e = boo.WrapType(e, boo.InvalidRead, "failed to extract submission")
 		return
 	}
-	transport, e := submission.ToTransport()
+	// The stamp is checked before the signature, as it is far cheaper to verify.
+	hash = cipher.SumSHA256(submission.Raw)
+	body, e := object.NewBody(submission.Raw)
 	if e != nil {
-		e = boo.WrapType(e, boo.InvalidRead, "failed to extract transport")
+		e = boo.WrapType(e, boo.InvalidRead, "failed to extract body")
+		return
+	}
+	bpk, e := body.GetOfBoard()
+	if e != nil {
+		e = boo.WrapType(e, boo.InvalidRead, "failed to extract board public key")
 		return
-	} else {
-		hash = transport.Header.GetHash()
 	}
-	bi, e := r.compiler.GetBoard(transport.GetOfBoard())
+	bi, e := r.compiler.GetBoard(bpk)
 	if e != nil {
 		e = boo.WrapType(e, boo.InvalidRead, "failed to obtain board instance")
 		return
@@ -157,6 +162,15 @@ func (r *Relay) processSubmission(conn *factory.Connection, address string, pk c
 		e = boo.WrapType(e, boo.NotAllowed, "node does not own this board")
 		return
 	}
+	if e = bi.CheckStamp(hash); e != nil {
+		e = boo.WrapType(e, boo.Type(e), "submission rejected")
+		return
+	}
+	transport, e := submission.ToTransport()
+	if e != nil {
+		e = boo.WrapType(e, boo.InvalidRead, "failed to extract transport")
+		return
+	}
 	if goal, e = bi.Submit(transport); e != nil {
 		e = boo.WrapType(e, boo.Type(e), "submission failed")
 		return
@@ -169,46 +183,59 @@ func send(conn *factory.Connection, toPK cipher.PubKey, t Type, body []byte) err
 }
 
 func (r *Relay) SubmitToRemote(ctx context.Context, toPK cipher.PubKey, data interface{}) (uint64, error) {
-	if r.initialised.Value() == false {
-		return 0, boo.New(boo.NotAllowed, "relay is not initialised - no available connections")
-	}
 	switch t := data.(type) {
 	case *Submission:
-
-		hash := data.(*Submission).GetHash()
-		resChan, e := r.incomplete.Add(hash)
+		res, e := r.Submit(ctx, toPK, t)
 		if e != nil {
 			return 0, e
 		}
-		defer r.incomplete.Remove(hash)
+		return res.Seq, res.Error()
 
-		var (
-			done   bool
-			eStack error
-		)
-		r.factory.ForEachConn(func(conn *factory.Connection) {
-			if e := send(conn, toPK, SubmissionType, encoder.Serialize(data)); e != nil {
-				eStack = boo.Wrap(eStack, e.Error())
-			} else {
-				done = true
-			}
-		})
+	default:
+		return 0, boo.Newf(boo.InvalidInput, "invalid type '%T'", t)
+	}
+}
+
+// Submit sends a submission to the remote board of messenger key 'toPK', and waits for it's response.
+// An error is returned only if no response is obtained (the response may still be a rejection).
+func (r *Relay) Submit(ctx context.Context, toPK cipher.PubKey, submission *Submission) (*SubmissionResponse, error) {
+	if r.initialised.Value() == false {
+		return nil, boo.New(boo.NotAllowed, "relay is not initialised - no available connections")
+	}
 
-		if !done {
-			return 0, eStack
+	hash := submission.GetHash()
+	resChan, e := r.incomplete.Add(hash)
+	if e != nil {
+		return nil, e
+	}
+	defer r.incomplete.Remove(hash)
+
+	var (
+		done   bool
+		eStack error
+	)
+	r.factory.ForEachConn(func(conn *factory.Connection) {
+		if e := send(conn, toPK, SubmissionType, encoder.Serialize(submission)); e != nil {
+			eStack = boo.Wrap(eStack, e.Error())
+		} else {
+			done = true
 		}
+	})
 
-		for {
-			select {
-			case <-ctx.Done():
-				return 0, ctx.Err()
-			case res := <-resChan:
-				return res.Seq, res.Error()
-			}
+	if !done {
+		if eStack == nil {
+			eStack = boo.New(boo.NotFound, "no available connections")
 		}
+		return nil, eStack
+	}
 
-	default:
-		return 0, boo.Newf(boo.InvalidInput, "invalid type '%T'", t)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case res := <-resChan:
+			return res, nil
+		}
 	}
 }
 
@@ -27,6 +27,12 @@ func (g *Gateway) host(mux *http.ServeMux) error {
 	// For submission.
 	RegisterSubmissionHandlers(mux, g)
 
+	// For streaming.
+	RegisterStreamHandlers(mux, g)
+
+	// For debugging.
+	RegisterDebugHandlers(mux, g)
+
 	// Gets a list of boards; remote and master (boards that this node owns).
 	mux.HandleFunc("/api/get_boards",
 		func(w http.ResponseWriter, r *http.Request) {
@@ -48,6 +54,12 @@ func (g *Gateway) host(mux *http.ServeMux) error {
 			send(w)(g.Access.GetBoardPage(r.Context(), &store.BoardIn{
 				PubKeyStr:     r.FormValue("board_public_key"),
 				UserPubKeyStr: r.FormValue("perspective"),
+				SortModeStr:   r.FormValue("sort_mode"),
+				FilterIn: store.FilterIn{
+					FilterModeStr:    r.FormValue("filter_mode"),
+					TrustDepthStr:    r.FormValue("trust_depth"),
+					MinReputationStr: r.FormValue("min_reputation"),
+				},
 			}))
 		})
 
@@ -58,6 +70,89 @@ func (g *Gateway) host(mux *http.ServeMux) error {
 				BoardPubKeyStr: r.FormValue("board_public_key"),
 				ThreadRefStr:   r.FormValue("thread_ref"),
 				UserPubKeyStr:  r.FormValue("perspective"),
+				FilterIn: store.FilterIn{
+					FilterModeStr:    r.FormValue("filter_mode"),
+					TrustDepthStr:    r.FormValue("trust_depth"),
+					MinReputationStr: r.FormValue("min_reputation"),
+				},
+			}))
+		})
+
+	// Gets a view of thread and it's posts as a tree of replies.
+	mux.HandleFunc("/api/get_thread_tree",
+		func(w http.ResponseWriter, r *http.Request) {
+			send(w)(g.Access.GetThreadTree(r.Context(), &store.ThreadTreeIn{
+				ThreadIn: store.ThreadIn{
+					BoardPubKeyStr: r.FormValue("board_public_key"),
+					ThreadRefStr:   r.FormValue("thread_ref"),
+					UserPubKeyStr:  r.FormValue("perspective"),
+					FilterIn: store.FilterIn{
+						FilterModeStr:    r.FormValue("filter_mode"),
+						TrustDepthStr:    r.FormValue("trust_depth"),
+						MinReputationStr: r.FormValue("min_reputation"),
+					},
+				},
+				RootHashStr:  r.FormValue("root_hash"),
+				MaxDepthStr:  r.FormValue("max_depth"),
+				CollapsedStr: r.FormValue("collapsed"),
+				PaginatedIn: store.PaginatedIn{
+					StartIndexStr: r.FormValue("start_index"),
+					PageSizeStr:   r.FormValue("page_size"),
+				},
+			}))
+		})
+
+	// Lists the root sequences of a board that are kept locally, with timestamps.
+	mux.HandleFunc("/api/get_board_history",
+		func(w http.ResponseWriter, r *http.Request) {
+			send(w)(g.Access.GetBoardHistory(r.Context(), &store.BoardHistoryIn{
+				BoardPubKeyStr: r.FormValue("board_public_key"),
+			}))
+		})
+
+	// Gets a view of a board as of a past root sequence.
+	mux.HandleFunc("/api/get_board_page_at",
+		func(w http.ResponseWriter, r *http.Request) {
+			send(w)(g.Access.GetBoardPageAt(r.Context(), &store.BoardAtIn{
+				BoardIn: store.BoardIn{
+					PubKeyStr:     r.FormValue("board_public_key"),
+					UserPubKeyStr: r.FormValue("perspective"),
+					SortModeStr:   r.FormValue("sort_mode"),
+					FilterIn: store.FilterIn{
+						FilterModeStr:    r.FormValue("filter_mode"),
+						TrustDepthStr:    r.FormValue("trust_depth"),
+						MinReputationStr: r.FormValue("min_reputation"),
+					},
+				},
+				SeqStr: r.FormValue("seq"),
+			}))
+		})
+
+	// Gets a view of a thread as of a past root sequence.
+	mux.HandleFunc("/api/get_thread_page_at",
+		func(w http.ResponseWriter, r *http.Request) {
+			send(w)(g.Access.GetThreadPageAt(r.Context(), &store.ThreadAtIn{
+				ThreadIn: store.ThreadIn{
+					BoardPubKeyStr: r.FormValue("board_public_key"),
+					ThreadRefStr:   r.FormValue("thread_ref"),
+					UserPubKeyStr:  r.FormValue("perspective"),
+					FilterIn: store.FilterIn{
+						FilterModeStr:    r.FormValue("filter_mode"),
+						TrustDepthStr:    r.FormValue("trust_depth"),
+						MinReputationStr: r.FormValue("min_reputation"),
+					},
+				},
+				SeqStr: r.FormValue("seq"),
+			}))
+		})
+
+	// Gets the changes of a board between two root sequences.
+	mux.HandleFunc("/api/diff_board",
+		func(w http.ResponseWriter, r *http.Request) {
+			send(w)(g.Access.DiffBoard(r.Context(), &store.DiffBoardIn{
+				BoardPubKeyStr: r.FormValue("board_public_key"),
+				FromSeqStr:     r.FormValue("from_seq"),
+				ToSeqStr:       r.FormValue("to_seq"),
 			}))
 		})
 
@@ -67,6 +162,47 @@ func (g *Gateway) host(mux *http.ServeMux) error {
 			send(w)(g.Access.GetFollowPage(r.Context(), &store.UserIn{
 				BoardPubKeyStr: r.FormValue("board_public_key"),
 				UserPubKeyStr:  r.FormValue("user_public_key"),
+				PerspectiveStr: r.FormValue("perspective"),
+			}))
+		})
+
+	// Gets the threads, posts and votes submitted by specified user.
+	mux.HandleFunc("/api/get_user_submissions",
+		func(w http.ResponseWriter, r *http.Request) {
+			send(w)(g.Access.GetUserSubmissions(r.Context(), &store.UserSubmissionsIn{
+				BoardPubKeyStr: r.FormValue("board_public_key"),
+				UserPubKeyStr:  r.FormValue("user_public_key"),
+				TypesStr:       r.FormValue("types"),
+				ReverseStr:     r.FormValue("reverse"),
+				PaginatedIn: store.PaginatedIn{
+					StartIndexStr: r.FormValue("start_index"),
+					PageSizeStr:   r.FormValue("page_size"),
+				},
+			}))
+		})
+
+	// Gets the signed votes behind the vote tally of a thread, post or user.
+	mux.HandleFunc("/api/get_vote_audit",
+		func(w http.ResponseWriter, r *http.Request) {
+			send(w)(g.Access.GetVoteAudit(r.Context(), &store.VoteAuditIn{
+				BoardPubKeyStr: r.FormValue("board_public_key"),
+				RefStr:         r.FormValue("ref"),
+				PaginatedIn: store.PaginatedIn{
+					StartIndexStr: r.FormValue("start_index"),
+					PageSizeStr:   r.FormValue("page_size"),
+				},
+			}))
+		})
+
+	// Gets received content of a board that failed signature verification.
+	mux.HandleFunc("/api/get_quarantine",
+		func(w http.ResponseWriter, r *http.Request) {
+			send(w)(g.Access.GetQuarantine(r.Context(), &store.QuarantineIn{
+				BoardPubKeyStr: r.FormValue("board_public_key"),
+				PaginatedIn: store.PaginatedIn{
+					StartIndexStr: r.FormValue("start_index"),
+					PageSizeStr:   r.FormValue("page_size"),
+				},
 			}))
 		})
 
@@ -74,10 +210,57 @@ func (g *Gateway) host(mux *http.ServeMux) error {
 	mux.HandleFunc("/api/get_participants",
 		func(w http.ResponseWriter, r *http.Request) {
 			send(w)(g.Access.GetParticipants(r.Context(), &store.BoardIn{
-				PubKeyStr: r.FormValue("board_public_key"),
+				PubKeyStr:     r.FormValue("board_public_key"),
+				UserPubKeyStr: r.FormValue("perspective"),
+				SortModeStr:   r.FormValue("sort_mode"),
+				FilterIn: store.FilterIn{
+					MinReputationStr: r.FormValue("min_reputation"),
+				},
+			}))
+		})
+
+	// Gets statistics of a board.
+	mux.HandleFunc("/api/get_board_stats",
+		func(w http.ResponseWriter, r *http.Request) {
+			send(w)(g.Access.GetBoardStats(r.Context(), &store.BoardStatsIn{
+				BoardPubKeyStr: r.FormValue("board_public_key"),
+				DaysStr:        r.FormValue("days"),
+				TopCountStr:    r.FormValue("top_count"),
+			}))
+		})
+
+	// Searches threads and posts of subscribed boards.
+	mux.HandleFunc("/api/search",
+		func(w http.ResponseWriter, r *http.Request) {
+			send(w)(g.Access.Search(r.Context(), &store.SearchIn{
+				BoardPubKeyStr: r.FormValue("board_public_key"),
+				UserPubKeyStr:  r.FormValue("perspective"),
+				Query:          r.FormValue("query"),
+				PaginatedIn: store.PaginatedIn{
+					StartIndexStr: r.FormValue("start_index"),
+					PageSizeStr:   r.FormValue("page_size"),
+				},
 			}))
 		})
 
+	// Gets the most recent threads and posts of subscribed boards.
+	mux.HandleFunc("/api/get_feed",
+		func(w http.ResponseWriter, r *http.Request) {
+			send(w)(g.Access.GetFeed(r.Context(), &store.FeedIn{
+				BoardsStr:   r.FormValue("boards"),
+				TypesStr:    r.FormValue("types"),
+				AuthorsStr:  r.FormValue("authors"),
+				CursorStr:   r.FormValue("cursor"),
+				PageSizeStr: r.FormValue("page_size"),
+			}))
+		})
+
+	// Lists submissions to remote boards that are kept to be retried, and their status.
+	mux.HandleFunc("/api/outbox",
+		func(w http.ResponseWriter, r *http.Request) {
+			send(w)(g.Access.GetOutbox(r.Context()))
+		})
+
 	// Lists boards that have been discovered, but not subscribed to.
 	mux.HandleFunc("/api/get_available_boards",
 		func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,39 @@
+package http
+
+import (
+	"github.com/skycoin/bbs/src/misc/boo"
+	"github.com/skycoin/bbs/src/store"
+	"net/http"
+)
+
+func RegisterDebugHandlers(mux *http.ServeMux, g *Gateway) {
+
+	// Compares the compiled views of a board against the content of it's root.
+	// If 'repair' is true (only accepted with POST), inconsistent views are recompiled.
+	mux.HandleFunc("/api/debug/check_board",
+		func(w http.ResponseWriter, r *http.Request) {
+			in := &store.CheckBoardIn{
+				BoardPubKeyStr: r.FormValue("board_public_key"),
+				RepairStr:      r.FormValue("repair"),
+			}
+			if in.RepairStr != "" && r.Method != http.MethodPost {
+				send(w)(nil, boo.New(boo.NotAllowed, "'repair' is only accepted with POST"))
+				return
+			}
+			send(w)(g.Access.CheckBoard(r.Context(), in))
+		})
+
+	// Gets publishing metrics (batch sizes and latencies) of a master board.
+	mux.HandleFunc("/api/debug/publish_stats",
+		func(w http.ResponseWriter, r *http.Request) {
+			send(w)(g.Access.GetPublishStats(r.Context(), &store.PublishStatsIn{
+				BoardPubKeyStr: r.FormValue("board_public_key"),
+			}))
+		})
+
+	// Gets metrics of the compiler's queue and per-board workers.
+	mux.HandleFunc("/api/debug/compiler_stats",
+		func(w http.ResponseWriter, r *http.Request) {
+			send(w)(g.Access.GetCompilerStats(r.Context()))
+		})
+}
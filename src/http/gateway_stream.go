@@ -0,0 +1,110 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/skycoin/bbs/src/misc/boo"
+	"github.com/skycoin/bbs/src/store"
+	"github.com/skycoin/bbs/src/store/state"
+	"net/http"
+	"time"
+)
+
+// streamKeepAliveInterval is the interval in which comments are sent to keep idle streams alive.
+const streamKeepAliveInterval = 15 * time.Second
+
+// streamResyncEvent is sent when missed events cannot be replayed on resume,
+// in which case clients should obtain views of the board anew.
+const streamResyncEvent = "resync"
+
+func RegisterStreamHandlers(mux *http.ServeMux, g *Gateway) {
+
+	// Streams changes of compiled boards as server-sent events.
+	// Streams of a single board can be resumed with 'since_seq' or the 'Last-Event-ID'
+	// header, which is set to the root sequence of the last fully received board update.
+	mux.HandleFunc("/api/stream",
+		func(w http.ResponseWriter, r *http.Request) {
+			flusher, ok := w.(http.Flusher)
+			if !ok {
+				sendErr(w, boo.New(boo.Internal, "streaming is not supported"))
+				return
+			}
+			bpk := r.FormValue("board_public_key")
+			resumable := bpk != ""
+			since := r.FormValue("since_seq")
+			if since == "" && resumable {
+				since = r.Header.Get("Last-Event-ID")
+			}
+			stream, e := g.Access.Stream(r.Context(), &store.StreamIn{
+				BoardPubKeyStr: bpk,
+				ThreadHashStr:  r.FormValue("thread_hash"),
+				TypesStr:       r.FormValue("types"),
+				SinceSeqStr:    since,
+			})
+			if e != nil {
+				sendErr(w, e)
+				return
+			}
+			defer stream.Close()
+
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Header().Set("Cache-Control", "no-cache")
+			w.Header().Set("Connection", "keep-alive")
+			w.WriteHeader(http.StatusOK)
+
+			if !stream.Complete {
+				fmt.Fprintf(w, "event: %s\ndata: {}\n\n", streamResyncEvent)
+			}
+			for _, event := range stream.Replay {
+				if e := writeStreamEvent(w, event, resumable); e != nil {
+					g.l.Println("stream write failed:", e)
+					return
+				}
+			}
+			flusher.Flush()
+
+			ticker := time.NewTicker(streamKeepAliveInterval)
+			defer ticker.Stop()
+
+			dropped := stream.Dropped()
+			for {
+				select {
+				case <-r.Context().Done():
+					return
+				case <-ticker.C:
+					fmt.Fprint(w, ": keep-alive\n\n")
+				case event, ok := <-stream.C():
+					if !ok {
+						return
+					}
+					if e := writeStreamEvent(w, event, resumable); e != nil {
+						g.l.Println("stream write failed:", e)
+						return
+					}
+				}
+				// Events are dropped when the client is too slow to keep up.
+				if n := stream.Dropped(); n > dropped {
+					dropped = n
+					fmt.Fprintf(w, "event: %s\ndata: {}\n\n", streamResyncEvent)
+				}
+				flusher.Flush()
+			}
+		})
+}
+
+// writeStreamEvent writes an event in server-sent event format.
+// The event id is only set for board updates of resumable streams, as that is
+// when all changes of a root sequence has been sent.
+func writeStreamEvent(w http.ResponseWriter, event *state.Event, resumable bool) error {
+	data, e := json.Marshal(event)
+	if e != nil {
+		return e
+	}
+	if resumable && event.Type == state.EventBoardUpdated {
+		if _, e := fmt.Fprintf(w, "id: %d\n", event.Seq); e != nil {
+			return e
+		}
+	}
+	_, e = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+	return e
+}
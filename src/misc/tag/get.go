@@ -78,6 +78,14 @@ func GetVoteValue(v string) (int8, error) {
 	return int8(value), nil
 }
 
+func GetUint(v string) (uint, error) {
+	value, e := strconv.ParseUint(strings.TrimSpace(v), 10, 0)
+	if e != nil {
+		return 0, boo.WrapType(e, boo.InvalidInput, "invalid unsigned integer")
+	}
+	return uint(value), nil
+}
+
 func GetTags(v string) ([]string, error) {
 	tags := strings.Split(v, ",")
 	for i := len(tags) - 1; i >= 0; i-- {
@@ -39,7 +39,7 @@ func TestSimple_Get(t *testing.T) {
 		if e != nil {
 			t.Error(e)
 		} else {
-			t.Log(out.Data, out.RemainingCount)
+			t.Log(out.Data, out.RecordCount)
 		}
 	})
 }
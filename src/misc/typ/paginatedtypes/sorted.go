@@ -0,0 +1,119 @@
+package paginatedtypes
+
+import (
+	"github.com/skycoin/bbs/src/misc/typ"
+	"sort"
+)
+
+// LessFunc reports whether element 'a' should be placed before element 'b'.
+type LessFunc func(a, b string) bool
+
+// NewSorted creates a new Sorted paginated type that orders it's elements
+// with the provided less function.
+func NewSorted(less LessFunc) *Sorted {
+	return &Sorted{
+		less: less,
+		dict: make(map[string]struct{}),
+	}
+}
+
+// Sorted keeps it's elements ordered as they are appended.
+// When the values used to order an element are to change, they should be changed
+// within 'Update', so that the element is located with it's previous values.
+type Sorted struct {
+	less LessFunc
+	list []string
+	dict map[string]struct{}
+}
+
+func (p *Sorted) Append(v string) {
+	if p.Has(v) {
+		return
+	}
+	p.dict[v] = struct{}{}
+	p.insert(v)
+}
+
+// Update re-positions an element while the values used to order it are changed by 'change'.
+func (p *Sorted) Update(v string, change func()) {
+	if !p.Remove(v) {
+		change()
+		return
+	}
+	change()
+	p.Append(v)
+}
+
+// Remove removes an element. The values used to order the element should be
+// unchanged since it was appended.
+func (p *Sorted) Remove(v string) bool {
+	if !p.Has(v) {
+		return false
+	}
+	delete(p.dict, v)
+	i := p.search(v)
+	p.list = append(p.list[:i], p.list[i+1:]...)
+	return true
+}
+
+func (p *Sorted) Has(v string) bool {
+	_, ok := p.dict[v]
+	return ok
+}
+
+func (p *Sorted) Get(in *typ.PaginatedInput) (*typ.PaginatedOutput, error) {
+	out, e := typ.NewPaginatedOutput(in, uint(len(p.list)))
+	if e != nil {
+		return nil, e
+	}
+
+	var action func(v uint) uint
+	if in.Reverse {
+		action = func(v uint) uint { return v - 1 }
+	} else {
+		action = func(v uint) uint { return v + 1 }
+	}
+	for i, j := uint(0), in.StartIndex; i < uint(len(out.Data)); i, j = i+1, action(j) {
+		out.Data[i] = p.list[j]
+	}
+
+	return out, nil
+}
+
+func (p *Sorted) Len() int {
+	return len(p.list)
+}
+
+func (p *Sorted) Clear() {
+	p.list = []string{}
+	p.dict = make(map[string]struct{})
+}
+
+func (p *Sorted) insert(v string) {
+	i := sort.Search(len(p.list), func(i int) bool {
+		return p.less(v, p.list[i])
+	})
+	p.list = append(p.list, "")
+	copy(p.list[i+1:], p.list[i:])
+	p.list[i] = v
+}
+
+// search obtains the index of an element that is in the list.
+func (p *Sorted) search(v string) int {
+	i := sort.Search(len(p.list), func(i int) bool {
+		return !p.less(p.list[i], v)
+	})
+	// Elements of equal order are placed in order of insertion.
+	for j := i; j < len(p.list) && !p.less(v, p.list[j]); j++ {
+		if p.list[j] == v {
+			return j
+		}
+	}
+	// Ordering values were changed outside of 'Update'.
+	for j, elem := range p.list {
+		if elem == v {
+			return j
+		}
+	}
+	return -1
+}
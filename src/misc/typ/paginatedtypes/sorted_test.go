@@ -0,0 +1,61 @@
+package paginatedtypes
+
+import (
+	"github.com/skycoin/bbs/src/misc/typ"
+	"math"
+	"testing"
+)
+
+func TestSorted_Fix(t *testing.T) {
+	rank := map[string]int{"a": 3, "b": 1, "c": 2}
+
+	p := NewSorted(func(a, b string) bool {
+		return rank[a] > rank[b]
+	})
+	for _, v := range []string{"a", "b", "c", "a"} {
+		p.Append(v)
+	}
+
+	check := func(t *testing.T, expected ...string) {
+		out, e := p.Get(&typ.PaginatedInput{PageSize: math.MaxUint32})
+		if e != nil {
+			t.Fatal(e)
+		}
+		if len(out.Data) != len(expected) {
+			t.Fatalf("got %v, expected %v", out.Data, expected)
+		}
+		for i, v := range expected {
+			if out.Data[i] != v {
+				t.Fatalf("got %v, expected %v", out.Data, expected)
+			}
+		}
+	}
+
+	t.Run("append", func(t *testing.T) {
+		check(t, "a", "c", "b")
+	})
+
+	t.Run("update", func(t *testing.T) {
+		p.Update("b", func() { rank["b"] = 10 })
+		check(t, "b", "a", "c")
+	})
+
+	t.Run("update equal", func(t *testing.T) {
+		p.Update("c", func() { rank["c"] = 3 })
+		check(t, "b", "a", "c")
+		p.Update("a", func() { rank["a"] = 3 })
+		check(t, "b", "c", "a")
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		if !p.Remove("c") {
+			t.Fatal("expected element to be removed")
+		}
+		if p.Remove("c") {
+			t.Fatal("expected removed element to not be found")
+		}
+		check(t, "b", "a")
+		p.Append("c")
+		check(t, "b", "a", "c")
+	})
+}
@@ -97,6 +97,22 @@ func DeleteBoard(in *store.BoardIn) (string, interface{}) {
 	return method("DeleteBoard"), in
 }
 
+func PublishBoardDeletion(in *store.BoardDeletionIn) (string, interface{}) {
+	return method("PublishBoardDeletion"), in
+}
+
+func SetBoardPoW(in *store.BoardPoWIn) (string, interface{}) {
+	return method("SetBoardPoW"), in
+}
+
+func TransferBoard(in *store.TransferBoardIn) (string, interface{}) {
+	return method("TransferBoard"), in
+}
+
+func ForkBoard(in *store.ForkBoardIn) (string, interface{}) {
+	return method("ForkBoard"), in
+}
+
 func ExportBoard(in *store.ExportBoardIn) (string, interface{}) {
 	in.FilePath, _ = filepath.Abs(in.FilePath)
 	return method("ExportBoard"), in
@@ -123,14 +139,54 @@ func GetBoardPage(in *store.BoardIn) (string, interface{}) {
 	return method("GetBoardPage"), in
 }
 
+func GetParticipants(in *store.BoardIn) (string, interface{}) {
+	return method("GetParticipants"), in
+}
+
 func GetThreadPage(in *store.ThreadIn) (string, interface{}) {
 	return method("GetThreadPage"), in
 }
 
+func GetThreadTree(in *store.ThreadTreeIn) (string, interface{}) {
+	return method("GetThreadTree"), in
+}
+
+func GetBoardHistory(in *store.BoardHistoryIn) (string, interface{}) {
+	return method("GetBoardHistory"), in
+}
+
+func GetBoardPageAt(in *store.BoardAtIn) (string, interface{}) {
+	return method("GetBoardPageAt"), in
+}
+
+func GetThreadPageAt(in *store.ThreadAtIn) (string, interface{}) {
+	return method("GetThreadPageAt"), in
+}
+
+func DiffBoard(in *store.DiffBoardIn) (string, interface{}) {
+	return method("DiffBoard"), in
+}
+
 func GetFollowPage(in *store.UserIn) (string, interface{}) {
 	return method("GetFollowPage"), in
 }
 
+func GetUserSubmissions(in *store.UserSubmissionsIn) (string, interface{}) {
+	return method("GetUserSubmissions"), in
+}
+
+func GetBoardStats(in *store.BoardStatsIn) (string, interface{}) {
+	return method("GetBoardStats"), in
+}
+
+func Search(in *store.SearchIn) (string, interface{}) {
+	return method("Search"), in
+}
+
+func GetFeed(in *store.FeedIn) (string, interface{}) {
+	return method("GetFeed"), in
+}
+
 /*
 	<<< CONTENT : SUBMISSION >>>
 */
@@ -155,6 +211,18 @@ func VoteUser(in *store.VoteUserIn) (string, interface{}) {
 	return method("VoteUser"), in
 }
 
+func GetVoteAudit(in *store.VoteAuditIn) (string, interface{}) {
+	return method("GetVoteAudit"), in
+}
+
+func GetQuarantine(in *store.QuarantineIn) (string, interface{}) {
+	return method("GetQuarantine"), in
+}
+
+func GetOutbox() (string, interface{}) {
+	return method("GetOutbox"), empty()
+}
+
 /*
 	<<< HELPER FUNCTIONS >>>
 */
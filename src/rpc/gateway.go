@@ -89,6 +89,22 @@ func (g *Gateway) DeleteBoard(in *store.BoardIn, out *string) error {
 	return send(out)(g.Access.DeleteBoard(context.Background(), in))
 }
 
+func (g *Gateway) PublishBoardDeletion(in *store.BoardDeletionIn, out *string) error {
+	return send(out)(g.Access.PublishBoardDeletion(context.Background(), in))
+}
+
+func (g *Gateway) SetBoardPoW(in *store.BoardPoWIn, out *string) error {
+	return send(out)(g.Access.SetBoardPoW(context.Background(), in))
+}
+
+func (g *Gateway) TransferBoard(in *store.TransferBoardIn, out *string) error {
+	return send(out)(g.Access.TransferBoard(context.Background(), in))
+}
+
+func (g *Gateway) ForkBoard(in *store.ForkBoardIn, out *string) error {
+	return send(out)(g.Access.ForkBoard(context.Background(), in))
+}
+
 func (g *Gateway) ExportBoard(in *store.ExportBoardIn, out *string) error {
 	return send(out)(g.Access.ExportBoard(context.Background(), in))
 }
@@ -113,14 +129,54 @@ func (g *Gateway) GetBoardPage(in *store.BoardIn, out *string) error {
 	return send(out)(g.Access.GetBoardPage(context.Background(), in))
 }
 
+func (g *Gateway) GetParticipants(in *store.BoardIn, out *string) error {
+	return send(out)(g.Access.GetParticipants(context.Background(), in))
+}
+
 func (g *Gateway) GetThreadPage(in *store.ThreadIn, out *string) error {
 	return send(out)(g.Access.GetThreadPage(context.Background(), in))
 }
 
+func (g *Gateway) GetThreadTree(in *store.ThreadTreeIn, out *string) error {
+	return send(out)(g.Access.GetThreadTree(context.Background(), in))
+}
+
+func (g *Gateway) GetBoardHistory(in *store.BoardHistoryIn, out *string) error {
+	return send(out)(g.Access.GetBoardHistory(context.Background(), in))
+}
+
+func (g *Gateway) GetBoardPageAt(in *store.BoardAtIn, out *string) error {
+	return send(out)(g.Access.GetBoardPageAt(context.Background(), in))
+}
+
+func (g *Gateway) GetThreadPageAt(in *store.ThreadAtIn, out *string) error {
+	return send(out)(g.Access.GetThreadPageAt(context.Background(), in))
+}
+
+func (g *Gateway) DiffBoard(in *store.DiffBoardIn, out *string) error {
+	return send(out)(g.Access.DiffBoard(context.Background(), in))
+}
+
 func (g *Gateway) GetFollowPage(in *store.UserIn, out *string) error {
 	return send(out)(g.Access.GetFollowPage(context.Background(), in))
 }
 
+func (g *Gateway) GetUserSubmissions(in *store.UserSubmissionsIn, out *string) error {
+	return send(out)(g.Access.GetUserSubmissions(context.Background(), in))
+}
+
+func (g *Gateway) GetBoardStats(in *store.BoardStatsIn, out *string) error {
+	return send(out)(g.Access.GetBoardStats(context.Background(), in))
+}
+
+func (g *Gateway) Search(in *store.SearchIn, out *string) error {
+	return send(out)(g.Access.Search(context.Background(), in))
+}
+
+func (g *Gateway) GetFeed(in *store.FeedIn, out *string) error {
+	return send(out)(g.Access.GetFeed(context.Background(), in))
+}
+
 /*
 	<<< CONTENT : SUBMISSION >>>
 */
@@ -145,6 +201,18 @@ func (g *Gateway) VoteUser(in *store.VoteUserIn, out *string) error {
 	return send(out)(g.Access.VoteUser(context.Background(), in))
 }
 
+func (g *Gateway) GetVoteAudit(in *store.VoteAuditIn, out *string) error {
+	return send(out)(g.Access.GetVoteAudit(context.Background(), in))
+}
+
+func (g *Gateway) GetQuarantine(in *store.QuarantineIn, out *string) error {
+	return send(out)(g.Access.GetQuarantine(context.Background(), in))
+}
+
+func (g *Gateway) GetOutbox(_ *struct{}, out *string) error {
+	return send(out)(g.Access.GetOutbox(context.Background()))
+}
+
 /*
 	<<< HELPER FUNCTIONS >>>
 */
@@ -8,6 +8,7 @@ import (
 	"github.com/skycoin/bbs/src/store/medial"
 	"github.com/skycoin/bbs/src/store/object"
 	"github.com/skycoin/bbs/src/store/state"
+	"github.com/skycoin/skycoin/src/cipher"
 	"github.com/skycoin/skycoin/src/util/file"
 	"log"
 	"math"
@@ -24,6 +25,9 @@ func (a *Access) PrepareThread(ctx context.Context, in *PrepareThreadIn) (*Prepa
 	if e := in.Process(); e != nil {
 		return nil, e
 	}
+	if e := stampBody(ctx, a, in.Data); e != nil {
+		return nil, e
+	}
 	if hash, raw, e := a.Medial.Add(in.CreatorPubKey, in.Data); e != nil {
 		return nil, e
 	} else {
@@ -38,6 +42,9 @@ func (a *Access) PreparePost(ctx context.Context, in *PreparePostIn) (*PrepareOu
 	if e := in.Process(); e != nil {
 		return nil, e
 	}
+	if e := stampBody(ctx, a, in.Data); e != nil {
+		return nil, e
+	}
 	if hash, raw, e := a.Medial.Add(in.CreatorPubKey, in.Data); e != nil {
 		return nil, e
 	} else {
@@ -52,6 +59,9 @@ func (a *Access) PrepareThreadVote(ctx context.Context, in *PrepareThreadVoteIn)
 	if e := in.Process(); e != nil {
 		return nil, e
 	}
+	if e := stampBody(ctx, a, in.Data); e != nil {
+		return nil, e
+	}
 	if hash, raw, e := a.Medial.Add(in.CreatorPubKey, in.Data); e != nil {
 		return nil, e
 	} else {
@@ -66,6 +76,9 @@ func (a *Access) PreparePostVote(ctx context.Context, in *PreparePostVoteIn) (*P
 	if e := in.Process(); e != nil {
 		return nil, e
 	}
+	if e := stampBody(ctx, a, in.Data); e != nil {
+		return nil, e
+	}
 	if hash, raw, e := a.Medial.Add(in.CreatorPubKey, in.Data); e != nil {
 		return nil, e
 	} else {
@@ -80,6 +93,9 @@ func (a *Access) PrepareUserVote(ctx context.Context, in *PrepareUserVoteIn) (*P
 	if e := in.Process(); e != nil {
 		return nil, e
 	}
+	if e := stampBody(ctx, a, in.Data); e != nil {
+		return nil, e
+	}
 	if hash, raw, e := a.Medial.Add(in.CreatorPubKey, in.Data); e != nil {
 		return nil, e
 	} else {
@@ -165,6 +181,37 @@ func submitAndWait(ctx context.Context, a *Access, transport *object.Transport)
 	return bi, bi.WaitSeq(ctx, goal)
 }
 
+// stampBody adds a proof-of-work stamp to the prepared body if it's board requires one.
+func stampBody(ctx context.Context, a *Access, data *object.Body) error {
+	bpk, e := data.GetOfBoard()
+	if e != nil {
+		return nil
+	}
+	bi, e := a.CXO.GetBoardInstance(bpk)
+	if e != nil {
+		return nil
+	}
+	if n := bi.GetPoWBits(); n > 0 {
+		if _, _, e := data.SetStamp(ctx, n); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// stampTransport re-signs the transport with a proof-of-work stamp if the board requires one.
+func stampTransport(ctx context.Context, bi *state.BoardInstance, transport *object.Transport, sk cipher.SecKey) (*object.Transport, error) {
+	n := bi.GetPoWBits()
+	if n == 0 {
+		return transport, nil
+	}
+	hash, raw, e := transport.Body.SetStamp(ctx, n)
+	if e != nil {
+		return nil, e
+	}
+	return object.NewTransport(raw, cipher.SignHash(hash, sk))
+}
+
 /*
 	<<< CONNECTIONS : MESSENGER >>>
 */
@@ -280,6 +327,62 @@ func (a *Access) DeleteBoard(ctx context.Context, in *BoardIn) (*BoardsOut, erro
 	return a.GetBoards(ctx)
 }
 
+// PublishBoardDeletion marks a master board as deleted in a final published root.
+// Subscribers of the board are notified of the deletion, reason and successor.
+func (a *Access) PublishBoardDeletion(ctx context.Context, in *BoardDeletionIn) (*BoardsOut, error) {
+	if e := in.Process(); e != nil {
+		return nil, e
+	}
+	notice := &object.BoardDeletion{
+		Reason:    in.Reason,
+		Successor: in.SuccessorStr,
+		TS:        time.Now().UnixNano(),
+	}
+	if e := a.CXO.PublishBoardDeletion(in.BoardPubKey, notice); e != nil {
+		return nil, e
+	}
+	return a.GetBoards(ctx)
+}
+
+// SetBoardPoW sets the proof-of-work difficulty that a master board requires of submissions.
+func (a *Access) SetBoardPoW(ctx context.Context, in *BoardPoWIn) (*BoardsOut, error) {
+	if e := in.Process(); e != nil {
+		return nil, e
+	}
+	if e := a.CXO.SetBoardPoW(in.BoardPubKey, in.PoWBits); e != nil {
+		return nil, e
+	}
+	return a.GetBoards(ctx)
+}
+
+// TransferBoard migrates a master board to a new keypair, carrying over it's content.
+// Subscribers of the board follow the new keypair.
+func (a *Access) TransferBoard(ctx context.Context, in *TransferBoardIn) (*BoardsOut, error) {
+	if e := in.Process(); e != nil {
+		return nil, e
+	}
+	if e := a.CXO.TransferBoard(ctx, in.BoardPubKey, in.NewPubKey, in.NewSecKey); e != nil {
+		return nil, e
+	}
+	return a.GetBoards(ctx)
+}
+
+// ForkBoard creates a new master board from a remote board, replaying it's signed submissions.
+func (a *Access) ForkBoard(ctx context.Context, in *ForkBoardIn) (*ForkBoardOut, error) {
+	if e := in.Process(); e != nil {
+		return nil, e
+	}
+	replay, e := a.CXO.ForkBoard(ctx, in.BoardPubKey, in.NewPubKey, in.NewSecKey, in.Name)
+	if e != nil {
+		return nil, e
+	}
+	boards, e := a.GetBoards(ctx)
+	if e != nil {
+		return nil, e
+	}
+	return getForkBoardOut(in.NewPubKey, replay, boards), nil
+}
+
 func (a *Access) ExportBoard(ctx context.Context, in *ExportBoardIn) (*ExportBoardOut, error) {
 	if e := in.Process(); e != nil {
 		return nil, e
@@ -346,6 +449,8 @@ func (a *Access) GetBoardPage(ctx context.Context, in *BoardIn) (interface{}, er
 	}
 	return bi.Viewer().GetBoardPage(&state.BoardPageIn{
 		Perspective:    in.UserPubKeyStr,
+		SortMode:       in.SortMode,
+		Filter:         in.Filter,
 		PaginatedInput: typ.PaginatedInput{PageSize: math.MaxUint64},
 	})
 }
@@ -358,6 +463,9 @@ func (a *Access) NewThread(ctx context.Context, in *NewThreadIn) (interface{}, e
 	if e != nil {
 		return nil, e
 	}
+	if in.Transport, e = stampTransport(ctx, bi, in.Transport, in.CreatorSecKey); e != nil {
+		return nil, e
+	}
 	var goal uint64
 	if bi.IsMaster() {
 		if goal, e = bi.Submit(in.Transport); e != nil {
@@ -390,10 +498,97 @@ func (a *Access) GetThreadPage(ctx context.Context, in *ThreadIn) (interface{},
 	return bi.Viewer().GetThreadPage(&state.ThreadPageIn{
 		Perspective:    in.UserPubKeyStr,
 		ThreadHash:     in.ThreadRefStr,
+		Filter:         in.Filter,
+		PaginatedInput: typ.PaginatedInput{PageSize: math.MaxUint64},
+	})
+}
+
+// GetBoardHistory lists the root sequences of a board that are kept locally.
+func (a *Access) GetBoardHistory(ctx context.Context, in *BoardHistoryIn) ([]*state.RootInfo, error) {
+	if e := in.Process(); e != nil {
+		return nil, e
+	}
+	bi, e := a.CXO.GetBoardInstance(in.BoardPubKey)
+	if e != nil {
+		return nil, e
+	}
+	return bi.GetRoots()
+}
+
+// GetBoardPageAt obtains a view of a board as of a past root sequence.
+func (a *Access) GetBoardPageAt(ctx context.Context, in *BoardAtIn) (interface{}, error) {
+	if e := in.Process(); e != nil {
+		return nil, e
+	}
+	bi, e := a.CXO.GetBoardInstance(in.PubKey)
+	if e != nil {
+		return nil, e
+	}
+	v, e := bi.GetViewerAt(in.Seq)
+	if e != nil {
+		return nil, e
+	}
+	return v.GetBoardPage(&state.BoardPageIn{
+		Perspective:    in.UserPubKeyStr,
+		SortMode:       in.SortMode,
+		Filter:         in.Filter,
+		PaginatedInput: typ.PaginatedInput{PageSize: math.MaxUint64},
+	})
+}
+
+// GetThreadPageAt obtains a view of a thread as of a past root sequence.
+func (a *Access) GetThreadPageAt(ctx context.Context, in *ThreadAtIn) (interface{}, error) {
+	if e := in.Process(); e != nil {
+		return nil, e
+	}
+	bi, e := a.CXO.GetBoardInstance(in.BoardPubKey)
+	if e != nil {
+		return nil, e
+	}
+	v, e := bi.GetViewerAt(in.Seq)
+	if e != nil {
+		return nil, e
+	}
+	return v.GetThreadPage(&state.ThreadPageIn{
+		Perspective:    in.UserPubKeyStr,
+		ThreadHash:     in.ThreadRefStr,
+		Filter:         in.Filter,
 		PaginatedInput: typ.PaginatedInput{PageSize: math.MaxUint64},
 	})
 }
 
+// DiffBoard obtains the changes of a board between two root sequences.
+func (a *Access) DiffBoard(ctx context.Context, in *DiffBoardIn) (*state.BoardDiffOut, error) {
+	if e := in.Process(); e != nil {
+		return nil, e
+	}
+	bi, e := a.CXO.GetBoardInstance(in.BoardPubKey)
+	if e != nil {
+		return nil, e
+	}
+	return bi.Diff(in.FromSeq, in.ToSeq)
+}
+
+func (a *Access) GetThreadTree(ctx context.Context, in *ThreadTreeIn) (*state.ThreadTreeOut, error) {
+	if e := in.Process(); e != nil {
+		return nil, e
+	}
+	bi, e := a.CXO.GetBoardInstance(in.BoardPubKey)
+	if e != nil {
+		return nil, e
+	}
+	return bi.Viewer().GetThreadTree(&state.ThreadTreeIn{
+		Perspective: in.UserPubKeyStr,
+		ThreadHash:  in.ThreadRefStr,
+		Filter:      in.Filter,
+		RootHash:    in.RootHashStr,
+		MaxDepth:    in.MaxDepth,
+		StartIndex:  int(in.Paginated.StartIndex),
+		PageSize:    int(in.Paginated.PageSize),
+		Collapsed:   in.Collapsed,
+	})
+}
+
 func (a *Access) NewPost(ctx context.Context, in *NewPostIn) (interface{}, error) {
 	if e := in.Process(); e != nil {
 		return nil, e
@@ -402,6 +597,9 @@ func (a *Access) NewPost(ctx context.Context, in *NewPostIn) (interface{}, error
 	if e != nil {
 		return nil, e
 	}
+	if in.Transport, e = stampTransport(ctx, bi, in.Transport, in.CreatorSecKey); e != nil {
+		return nil, e
+	}
 	var goal uint64
 	if bi.IsMaster() {
 		if goal, e = bi.Submit(in.Transport); e != nil {
@@ -431,7 +629,120 @@ func (a *Access) GetParticipants(ctx context.Context, in *BoardIn) (interface{},
 	if e != nil {
 		return nil, e
 	}
-	return bi.Viewer().GetParticipants()
+	return bi.Viewer().GetParticipants(&state.ParticipantsIn{
+		Perspective:      in.UserPubKeyStr,
+		SortByReputation: in.SortMode == state.SortReputation,
+		MinReputation:    in.Filter.MinReputation,
+	})
+}
+
+func (a *Access) GetBoardStats(ctx context.Context, in *BoardStatsIn) (*state.BoardStatsOut, error) {
+	if e := in.Process(); e != nil {
+		return nil, e
+	}
+	bi, e := a.CXO.GetBoardInstance(in.BoardPubKey)
+	if e != nil {
+		return nil, e
+	}
+	return bi.Viewer().GetBoardStats(&in.Stats)
+}
+
+/*
+	<<< SEARCH >>>
+*/
+
+func (a *Access) Search(ctx context.Context, in *SearchIn) (*SearchOut, error) {
+	if e := in.Process(); e != nil {
+		return nil, e
+	}
+	var pks []cipher.PubKey
+	if in.BoardPubKeyStr != "" {
+		pks = []cipher.PubKey{in.BoardPubKey}
+	} else {
+		pks = a.CXO.GetSubscriptions()
+	}
+	bis, e := resolveBoards(pks, a.CXO.GetBoardInstance, in.BoardPubKeyStr != "")
+	if e != nil {
+		return nil, e
+	}
+	var results []*state.SearchResult
+	for _, bi := range bis {
+		out, e := bi.Viewer().Search(&state.SearchIn{
+			Perspective: in.UserPubKeyStr,
+			Query:       in.Query,
+		})
+		if e != nil {
+			if in.BoardPubKeyStr != "" {
+				return nil, e
+			}
+			continue
+		}
+		results = append(results, out.Results...)
+	}
+	state.SortSearchResults(results)
+	return getSearchOut(in, results), nil
+}
+
+func (a *Access) GetFeed(ctx context.Context, in *FeedIn) (*FeedOut, error) {
+	if e := in.Process(); e != nil {
+		return nil, e
+	}
+	pks := in.Boards
+	if len(pks) == 0 {
+		pks = a.CXO.GetSubscriptions()
+	}
+	bis, e := resolveBoards(pks, a.CXO.GetBoardInstance, len(in.Boards) > 0)
+	if e != nil {
+		return nil, e
+	}
+	var items []*state.FeedItem
+	for _, bi := range bis {
+		// Obtain an extra item to determine whether there is a next page.
+		out, e := bi.Viewer().GetFeed(&state.FeedIn{
+			Types:   in.Types,
+			Authors: in.Authors,
+			After:   in.Cursor,
+			Limit:   in.PageSize + 1,
+		})
+		if e != nil {
+			if len(in.Boards) > 0 {
+				return nil, e
+			}
+			continue
+		}
+		items = append(items, out...)
+	}
+	return getFeedOut(in, items), nil
+}
+
+// resolveBoards obtains the board instances of the given public keys.
+// Keys of transferred boards resolve to the instances of their successors, hence
+// instances are only included once. Unless 'strict' is set, boards that cannot
+// be obtained are skipped.
+func resolveBoards(
+	pks []cipher.PubKey, resolve func(pk cipher.PubKey) (*state.BoardInstance, error), strict bool,
+) (
+	[]*state.BoardInstance, error,
+) {
+	var (
+		out  = make([]*state.BoardInstance, 0, len(pks))
+		seen = make(map[*state.BoardInstance]struct{}, len(pks))
+	)
+	for _, pk := range pks {
+		bi, e := resolve(pk)
+		if e != nil {
+			if strict {
+				return nil, e
+			}
+			continue
+		}
+		if _, ok := seen[bi]; ok {
+			continue
+		}
+		seen[bi] = struct{}{}
+		out = append(out, bi)
+	}
+	return out, nil
 }
 
 /*
@@ -447,7 +758,25 @@ func (a *Access) GetFollowPage(ctx context.Context, in *UserIn) (interface{}, er
 		return nil, e
 	}
 	return bi.Viewer().GetUserProfile(&state.UserProfileIn{
+		Perspective: in.PerspectiveStr,
+		UserPubKey:  in.UserPubKeyStr,
+	})
+}
+
+func (a *Access) GetUserSubmissions(ctx context.Context, in *UserSubmissionsIn) (*state.UserSubmissionsOut, error) {
+	if e := in.Process(); e != nil {
+		return nil, e
+	}
+	bi, e := a.CXO.GetBoardInstance(in.BoardPubKey)
+	if e != nil {
+		return nil, e
+	}
+	return bi.GetUserSubmissions(&state.UserSubmissionsIn{
 		UserPubKey: in.UserPubKeyStr,
+		Types:      in.Types,
+		StartIndex: int(in.Paginated.StartIndex),
+		PageSize:   int(in.Paginated.PageSize),
+		Reverse:    in.Reverse,
 	})
 }
 
@@ -459,6 +788,9 @@ func (a *Access) VoteUser(ctx context.Context, in *VoteUserIn) (interface{}, err
 	if e != nil {
 		return nil, e
 	}
+	if in.Transport, e = stampTransport(ctx, bi, in.Transport, in.CreatorSecKey); e != nil {
+		return nil, e
+	}
 	var goal uint64
 	if bi.IsMaster() {
 		if goal, e = bi.Submit(in.Transport); e != nil {
@@ -486,6 +818,9 @@ func (a *Access) VoteThread(ctx context.Context, in *VoteThreadIn) (interface{},
 	if e != nil {
 		return nil, e
 	}
+	if in.Transport, e = stampTransport(ctx, bi, in.Transport, in.CreatorSecKey); e != nil {
+		return nil, e
+	}
 	var goal uint64
 	if bi.IsMaster() {
 		if goal, e = bi.Submit(in.Transport); e != nil {
@@ -514,6 +849,9 @@ func (a *Access) VotePost(ctx context.Context, in *VotePostIn) (interface{}, err
 	if e != nil {
 		return nil, e
 	}
+	if in.Transport, e = stampTransport(ctx, bi, in.Transport, in.CreatorSecKey); e != nil {
+		return nil, e
+	}
 	var goal uint64
 	if bi.IsMaster() {
 		if goal, e = bi.Submit(in.Transport); e != nil {
@@ -533,3 +871,105 @@ func (a *Access) VotePost(ctx context.Context, in *VotePostIn) (interface{}, err
 		ContentHash: in.PostRefStr,
 	})
 }
+
+// GetVoteAudit obtains the signed votes behind the vote tally of a thread, post or user.
+func (a *Access) GetVoteAudit(ctx context.Context, in *VoteAuditIn) (*state.VoteAuditOut, error) {
+	if e := in.Process(); e != nil {
+		return nil, e
+	}
+	bi, e := a.CXO.GetBoardInstance(in.BoardPubKey)
+	if e != nil {
+		return nil, e
+	}
+	return bi.Viewer().GetVoteAudit(&state.VoteAuditIn{
+		Ref:        in.RefStr,
+		StartIndex: int(in.Paginated.StartIndex),
+		PageSize:   int(in.Paginated.PageSize),
+	})
+}
+
+// GetQuarantine obtains received content of a board that failed signature verification.
+func (a *Access) GetQuarantine(ctx context.Context, in *QuarantineIn) (*state.QuarantineOut, error) {
+	if e := in.Process(); e != nil {
+		return nil, e
+	}
+	bi, e := a.CXO.GetBoardInstance(in.BoardPubKey)
+	if e != nil {
+		return nil, e
+	}
+	return bi.Viewer().GetQuarantine(&state.QuarantineIn{
+		StartIndex: int(in.Paginated.StartIndex),
+		PageSize:   int(in.Paginated.PageSize),
+	})
+}
+
+// GetOutbox obtains the submissions to remote boards that are kept to be retried.
+func (a *Access) GetOutbox(ctx context.Context) (*OutboxOut, error) {
+	return getOutboxOut(a.CXO.GetOutbox()), nil
+}
+
+/*
+	<<< STREAM >>>
+*/
+
+// Stream streams changes of compiled boards.
+// It should be closed after use.
+type Stream struct {
+	*state.EventSubscription
+	Replay   []*state.Event // Events missed since resumed root sequence.
+	Complete bool           // Whether all events since resumed root sequence can be replayed.
+}
+
+// Stream subscribes to changes of compiled boards.
+func (a *Access) Stream(ctx context.Context, in *StreamIn) (*Stream, error) {
+	if e := in.Process(); e != nil {
+		return nil, e
+	}
+	if in.BoardPubKeyStr != "" {
+		if _, e := a.CXO.GetBoardInstance(in.BoardPubKey); e != nil {
+			return nil, e
+		}
+	}
+	out := new(Stream)
+	if in.Resume {
+		out.EventSubscription, out.Replay, out.Complete = a.CXO.Events().
+			SubscribeFrom(&in.Filter, 0, in.BoardPubKey.Hex(), in.SinceSeq)
+	} else {
+		out.EventSubscription, out.Complete = a.CXO.Events().
+			Subscribe(&in.Filter, 0), true
+	}
+	return out, nil
+}
+
+/*
+	<<< DEBUG >>>
+*/
+
+// CheckBoard compares the compiled views of a board against the content of it's root.
+func (a *Access) CheckBoard(ctx context.Context, in *CheckBoardIn) (*state.ConsistencyReport, error) {
+	if e := in.Process(); e != nil {
+		return nil, e
+	}
+	bi, e := a.CXO.GetBoardInstance(in.BoardPubKey)
+	if e != nil {
+		return nil, e
+	}
+	return bi.CheckConsistency(in.Repair)
+}
+
+// GetPublishStats obtains the publishing metrics of a master board.
+func (a *Access) GetPublishStats(ctx context.Context, in *PublishStatsIn) (*state.PublishStats, error) {
+	if e := in.Process(); e != nil {
+		return nil, e
+	}
+	bi, e := a.CXO.GetBoardInstance(in.BoardPubKey)
+	if e != nil {
+		return nil, e
+	}
+	return bi.GetPublishStats(), nil
+}
+
+// GetCompilerStats obtains metrics of the compiler and it's per-board workers.
+func (a *Access) GetCompilerStats(ctx context.Context) (*state.CompilerStats, error) {
+	return a.CXO.GetCompilerStats(), nil
+}
@@ -5,11 +5,18 @@ import (
 	"fmt"
 	"github.com/skycoin/bbs/src/misc/boo"
 	"github.com/skycoin/bbs/src/misc/tag"
+	"github.com/skycoin/bbs/src/misc/typ"
 	"github.com/skycoin/bbs/src/store/object"
+	"github.com/skycoin/bbs/src/store/state"
 	"github.com/skycoin/skycoin/src/cipher"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// DefaultPageSize is the page size used when none is specified.
+const DefaultPageSize = 20
+
 type SubmissionIn struct {
 	Body   []byte
 	SigStr string
@@ -43,6 +50,8 @@ type UserIn struct {
 	BoardPubKey    cipher.PubKey
 	UserPubKeyStr  string
 	UserPubKey     cipher.PubKey
+	PerspectiveStr string
+	Perspective    cipher.PubKey
 }
 
 func (a *UserIn) Process() error {
@@ -53,15 +62,94 @@ func (a *UserIn) Process() error {
 	if a.UserPubKey, e = tag.GetPubKey(a.UserPubKeyStr); e != nil {
 		return ErrProcess(e, "user's public key")
 	}
+	if a.PerspectiveStr != "" {
+		if a.Perspective, e = tag.GetPubKey(a.PerspectiveStr); e != nil {
+			return ErrProcess(e, "perspective")
+		}
+	}
 	return nil
 }
 
+// UserSubmissionsIn represents the input required to obtain the submissions of a user.
+type UserSubmissionsIn struct {
+	BoardPubKeyStr string
+	BoardPubKey    cipher.PubKey
+	UserPubKeyStr  string
+	UserPubKey     cipher.PubKey
+	TypesStr       string // Optional, comma-separated (thread, post, thread_vote, post_vote, user_vote).
+	Types          []object.ContentType
+	ReverseStr     string // Optional, whether to obtain most recent submissions first.
+	Reverse        bool
+	PaginatedIn
+}
+
+func (a *UserSubmissionsIn) Process() error {
+	var e error
+	if a.BoardPubKey, e = tag.GetPubKey(a.BoardPubKeyStr); e != nil {
+		return ErrProcess(e, "board public key")
+	}
+	if a.UserPubKey, e = tag.GetPubKey(a.UserPubKeyStr); e != nil {
+		return ErrProcess(e, "user's public key")
+	}
+	if a.Types, e = getContentTypes(a.TypesStr,
+		object.V5ThreadType, object.V5PostType,
+		object.V5ThreadVoteType, object.V5PostVoteType, object.V5UserVoteType,
+	); e != nil {
+		return ErrProcess(e, "submission types")
+	}
+	if a.ReverseStr != "" {
+		if a.Reverse, e = strconv.ParseBool(a.ReverseStr); e != nil {
+			return ErrProcess(e, "reverse")
+		}
+	}
+	return a.PaginatedIn.Process(state.DefaultSubmissionsPageSize)
+}
+
+// VoteAuditIn represents the input required to obtain the signed votes behind a tally.
+type VoteAuditIn struct {
+	BoardPubKeyStr string
+	BoardPubKey    cipher.PubKey
+	RefStr         string // Hash of thread or post, or public key of user.
+	PaginatedIn
+}
+
+func (a *VoteAuditIn) Process() error {
+	var e error
+	if a.BoardPubKey, e = tag.GetPubKey(a.BoardPubKeyStr); e != nil {
+		return ErrProcess(e, "board public key")
+	}
+	if _, e = tag.GetHash(a.RefStr); e != nil {
+		if _, e = tag.GetPubKey(a.RefStr); e != nil {
+			return ErrProcess(e, "reference (content hash or user public key)")
+		}
+	}
+	return a.PaginatedIn.Process(state.DefaultVoteAuditPageSize)
+}
+
+// QuarantineIn represents the input required to obtain quarantined content of a board.
+type QuarantineIn struct {
+	BoardPubKeyStr string
+	BoardPubKey    cipher.PubKey
+	PaginatedIn
+}
+
+func (a *QuarantineIn) Process() error {
+	var e error
+	if a.BoardPubKey, e = tag.GetPubKey(a.BoardPubKeyStr); e != nil {
+		return ErrProcess(e, "board public key")
+	}
+	return a.PaginatedIn.Process(state.DefaultQuarantinePageSize)
+}
+
 // BoardIn represents a subscription input.
 type BoardIn struct {
 	PubKeyStr     string
 	PubKey        cipher.PubKey
 	UserPubKeyStr string
 	UserPubKey    cipher.PubKey
+	SortModeStr   string
+	SortMode      state.SortMode
+	FilterIn
 }
 
 func (a *BoardIn) Process() error {
@@ -74,6 +162,108 @@ func (a *BoardIn) Process() error {
 			return ErrProcess(e, "user public key")
 		}
 	}
+	if a.SortMode, e = state.GetSortMode(a.SortModeStr); e != nil {
+		return ErrProcess(e, "sort mode")
+	}
+	return a.FilterIn.Process()
+}
+
+// BoardDeletionIn represents the input required to publish the deletion of a master board.
+type BoardDeletionIn struct {
+	BoardPubKeyStr string
+	BoardPubKey    cipher.PubKey
+	Reason         string // Optional.
+	SuccessorStr   string // Optional, public key of board that replaces the deleted board.
+}
+
+func (a *BoardDeletionIn) Process() error {
+	var e error
+	if a.BoardPubKey, e = tag.GetPubKey(a.BoardPubKeyStr); e != nil {
+		return ErrProcess(e, "board public key")
+	}
+	if a.SuccessorStr != "" {
+		if _, e = tag.GetPubKey(a.SuccessorStr); e != nil {
+			return ErrProcess(e, "successor public key")
+		}
+	}
+	return nil
+}
+
+// BoardPoWIn represents the input required to set the proof-of-work difficulty of a master board.
+type BoardPoWIn struct {
+	BoardPubKeyStr string
+	BoardPubKey    cipher.PubKey
+	PoWBitsStr     string
+	PoWBits        int
+}
+
+func (a *BoardPoWIn) Process() error {
+	var e error
+	if a.BoardPubKey, e = tag.GetPubKey(a.BoardPubKeyStr); e != nil {
+		return ErrProcess(e, "board public key")
+	}
+	n, e := tag.GetUint(a.PoWBitsStr)
+	if e != nil {
+		return ErrProcess(e, "proof-of-work difficulty")
+	}
+	a.PoWBits = int(n)
+	if e = object.CheckPoWBits(a.PoWBits); e != nil {
+		return ErrProcess(e, "proof-of-work difficulty")
+	}
+	return nil
+}
+
+// TransferBoardIn represents the input required to migrate a master board to a new keypair.
+type TransferBoardIn struct {
+	BoardPubKeyStr string
+	BoardPubKey    cipher.PubKey
+	Seed           string // Seed of the new keypair.
+	NewPubKey      cipher.PubKey
+	NewSecKey      cipher.SecKey
+}
+
+func (a *TransferBoardIn) Process() error {
+	var e error
+	if a.BoardPubKey, e = tag.GetPubKey(a.BoardPubKeyStr); e != nil {
+		return ErrProcess(e, "board public key")
+	}
+	if a.Seed == "" {
+		return boo.New(boo.InvalidInput, "seed of new keypair is required")
+	}
+	a.NewPubKey, a.NewSecKey = cipher.GenerateDeterministicKeyPair([]byte(a.Seed))
+	if a.NewPubKey == a.BoardPubKey {
+		return boo.New(boo.InvalidInput, "seed generates the keypair of the board")
+	}
+	return nil
+}
+
+// ForkBoardIn represents the input required to fork a remote board into a new master board.
+type ForkBoardIn struct {
+	BoardPubKeyStr string
+	BoardPubKey    cipher.PubKey
+	Seed           string // Seed of the fork's keypair.
+	Name           string // Optional, defaults to name of forked board.
+	NewPubKey      cipher.PubKey
+	NewSecKey      cipher.SecKey
+}
+
+func (a *ForkBoardIn) Process() error {
+	var e error
+	if a.BoardPubKey, e = tag.GetPubKey(a.BoardPubKeyStr); e != nil {
+		return ErrProcess(e, "board public key")
+	}
+	if a.Name != "" {
+		if e := tag.CheckName(a.Name); e != nil {
+			return ErrProcess(e, "name")
+		}
+	}
+	if a.Seed == "" {
+		return boo.New(boo.InvalidInput, "seed of fork's keypair is required")
+	}
+	a.NewPubKey, a.NewSecKey = cipher.GenerateDeterministicKeyPair([]byte(a.Seed))
+	if a.NewPubKey == a.BoardPubKey {
+		return boo.New(boo.InvalidInput, "seed generates the keypair of the board")
+	}
 	return nil
 }
 
@@ -109,6 +299,7 @@ type NewBoardIn struct {
 	BoardPubKey cipher.PubKey
 	BoardSecKey cipher.SecKey
 	TS          int64
+	PoWBits     int // Optional, proof-of-work difficulty required of submissions.
 	Content     *object.Content
 }
 
@@ -119,6 +310,9 @@ func (a *NewBoardIn) Process(subKeyTrans []*object.MessengerSubKeyTransport) err
 	if e := tag.CheckBody(a.Body); e != nil {
 		return ErrProcess(e, "body")
 	}
+	if e := object.CheckPoWBits(a.PoWBits); e != nil {
+		return ErrProcess(e, "proof-of-work difficulty")
+	}
 	if a.TS == 0 {
 		a.TS = time.Now().UnixNano()
 	}
@@ -138,6 +332,7 @@ func (a *NewBoardIn) Process(subKeyTrans []*object.MessengerSubKeyTransport) err
 		Name:    a.Name,
 		Body:    a.Body,
 		SubKeys: subKeys,
+		PoWBits: a.PoWBits,
 		Tags:    []string{},
 	})
 	return nil
@@ -150,6 +345,7 @@ type ThreadIn struct {
 	ThreadRef      cipher.SHA256
 	UserPubKeyStr  string
 	UserPubKey     cipher.PubKey
+	FilterIn
 }
 
 func (a *ThreadIn) Process() error {
@@ -165,6 +361,123 @@ func (a *ThreadIn) Process() error {
 			return ErrProcess(e, "user's public key")
 		}
 	}
+	return a.FilterIn.Process()
+}
+
+type ThreadTreeIn struct {
+	ThreadIn
+	RootHashStr  string // Optional.
+	RootHash     cipher.SHA256
+	MaxDepthStr  string // Optional.
+	MaxDepth     int
+	CollapsedStr string // Optional (comma separated post hashes).
+	Collapsed    []string
+	PaginatedIn
+}
+
+func (a *ThreadTreeIn) Process() error {
+	var e error
+	if e = a.ThreadIn.Process(); e != nil {
+		return e
+	}
+	if a.RootHashStr != "" {
+		if a.RootHash, e = tag.GetHash(a.RootHashStr); e != nil {
+			return ErrProcess(e, "root post hash")
+		}
+	}
+	if a.MaxDepthStr != "" {
+		depth, e := tag.GetUint(a.MaxDepthStr)
+		if e != nil {
+			return ErrProcess(e, "max depth")
+		}
+		a.MaxDepth = int(depth)
+	}
+	a.Collapsed = nil
+	for _, v := range splitList(a.CollapsedStr) {
+		if _, e = tag.GetHash(v); e != nil {
+			return ErrProcess(e, "collapsed post hash")
+		}
+		a.Collapsed = append(a.Collapsed, v)
+	}
+	return a.PaginatedIn.Process(state.DefaultTreePageSize)
+}
+
+// BoardHistoryIn represents the input required to list the root sequences of a board.
+type BoardHistoryIn struct {
+	BoardPubKeyStr string
+	BoardPubKey    cipher.PubKey
+}
+
+func (a *BoardHistoryIn) Process() error {
+	var e error
+	if a.BoardPubKey, e = tag.GetPubKey(a.BoardPubKeyStr); e != nil {
+		return ErrProcess(e, "board public key")
+	}
+	return nil
+}
+
+// BoardAtIn represents the input required to view a board as of a root sequence.
+type BoardAtIn struct {
+	BoardIn
+	SeqStr string
+	Seq    uint64
+}
+
+func (a *BoardAtIn) Process() error {
+	if e := a.BoardIn.Process(); e != nil {
+		return e
+	}
+	seq, e := tag.GetUint(a.SeqStr)
+	if e != nil {
+		return ErrProcess(e, "root sequence")
+	}
+	a.Seq = uint64(seq)
+	return nil
+}
+
+// ThreadAtIn represents the input required to view a thread as of a root sequence.
+type ThreadAtIn struct {
+	ThreadIn
+	SeqStr string
+	Seq    uint64
+}
+
+func (a *ThreadAtIn) Process() error {
+	if e := a.ThreadIn.Process(); e != nil {
+		return e
+	}
+	seq, e := tag.GetUint(a.SeqStr)
+	if e != nil {
+		return ErrProcess(e, "root sequence")
+	}
+	a.Seq = uint64(seq)
+	return nil
+}
+
+// DiffBoardIn represents the input required to obtain changes of a board between two root sequences.
+type DiffBoardIn struct {
+	BoardPubKeyStr string
+	BoardPubKey    cipher.PubKey
+	FromSeqStr     string
+	FromSeq        uint64
+	ToSeqStr       string
+	ToSeq          uint64
+}
+
+func (a *DiffBoardIn) Process() error {
+	var e error
+	if a.BoardPubKey, e = tag.GetPubKey(a.BoardPubKeyStr); e != nil {
+		return ErrProcess(e, "board public key")
+	}
+	from, e := tag.GetUint(a.FromSeqStr)
+	if e != nil {
+		return ErrProcess(e, "from root sequence")
+	}
+	to, e := tag.GetUint(a.ToSeqStr)
+	if e != nil {
+		return ErrProcess(e, "to root sequence")
+	}
+	a.FromSeq, a.ToSeq = uint64(from), uint64(to)
 	return nil
 }
 
@@ -458,6 +771,275 @@ func (a *VotePostIn) Process() error {
 	return nil
 }
 
+// FilterIn represents optional input for filtering content from the perspective of a user.
+type FilterIn struct {
+	FilterModeStr    string
+	TrustDepthStr    string
+	MinReputationStr string
+	Filter           state.FilterIn
+}
+
+func (a *FilterIn) Process() error {
+	var e error
+	if a.Filter.Mode, e = state.GetFilterMode(a.FilterModeStr); e != nil {
+		return ErrProcess(e, "filter mode")
+	}
+	if a.TrustDepthStr != "" {
+		depth, e := tag.GetUint(a.TrustDepthStr)
+		if e != nil {
+			return ErrProcess(e, "trust depth")
+		}
+		a.Filter.TrustDepth = int(depth)
+	}
+	if a.MinReputationStr != "" {
+		minRep, e := strconv.ParseFloat(a.MinReputationStr, 64)
+		if e != nil {
+			return ErrProcess(e, "min reputation")
+		}
+		a.Filter.MinReputation = &minRep
+	}
+	if e := a.Filter.Check(); e != nil {
+		return ErrProcess(e, "filter")
+	}
+	return nil
+}
+
+// PaginatedIn represents optional pagination input.
+type PaginatedIn struct {
+	StartIndexStr string
+	PageSizeStr   string
+	Paginated     typ.PaginatedInput
+}
+
+func (a *PaginatedIn) Process(defaultPageSize uint) error {
+	var e error
+	if a.StartIndexStr != "" {
+		if a.Paginated.StartIndex, e = tag.GetUint(a.StartIndexStr); e != nil {
+			return ErrProcess(e, "start index")
+		}
+	}
+	a.Paginated.PageSize = defaultPageSize
+	if a.PageSizeStr != "" {
+		if a.Paginated.PageSize, e = tag.GetUint(a.PageSizeStr); e != nil {
+			return ErrProcess(e, "page size")
+		}
+	}
+	if a.Paginated.PageSize == 0 {
+		return ErrProcess(nil, "page size")
+	}
+	return nil
+}
+
+// Bounds obtains the range of elements to include in a page of given total size.
+func (a *PaginatedIn) Bounds(total int) (int, int) {
+	start := int(a.Paginated.StartIndex)
+	if start > total {
+		start = total
+	}
+	end := total
+	if uint(total-start) > a.Paginated.PageSize {
+		end = start + int(a.Paginated.PageSize)
+	}
+	return start, end
+}
+
+type SearchIn struct {
+	BoardPubKeyStr string
+	BoardPubKey    cipher.PubKey
+	UserPubKeyStr  string
+	Query          string
+	PaginatedIn
+}
+
+func (a *SearchIn) Process() error {
+	var e error
+	if a.BoardPubKeyStr != "" {
+		if a.BoardPubKey, e = tag.GetPubKey(a.BoardPubKeyStr); e != nil {
+			return ErrProcess(e, "board public key")
+		}
+	}
+	if a.UserPubKeyStr != "" {
+		if _, e = tag.GetPubKey(a.UserPubKeyStr); e != nil {
+			return ErrProcess(e, "user public key")
+		}
+	}
+	if len(state.SearchTerms(a.Query)) == 0 {
+		return ErrProcess(nil, "search query")
+	}
+	return a.PaginatedIn.Process(DefaultPageSize)
+}
+
+// BoardStatsIn represents the input required to obtain board statistics.
+type BoardStatsIn struct {
+	BoardPubKeyStr string
+	BoardPubKey    cipher.PubKey
+	DaysStr        string // Optional.
+	TopCountStr    string // Optional.
+	Stats          state.BoardStatsIn
+}
+
+func (a *BoardStatsIn) Process() error {
+	var e error
+	if a.BoardPubKey, e = tag.GetPubKey(a.BoardPubKeyStr); e != nil {
+		return ErrProcess(e, "board public key")
+	}
+	if a.DaysStr != "" {
+		days, e := tag.GetUint(a.DaysStr)
+		if e != nil {
+			return ErrProcess(e, "days")
+		}
+		a.Stats.Days = int(days)
+	}
+	if a.TopCountStr != "" {
+		count, e := tag.GetUint(a.TopCountStr)
+		if e != nil {
+			return ErrProcess(e, "top count")
+		}
+		a.Stats.TopCount = int(count)
+	}
+	return nil
+}
+
+// FeedIn represents the input required to obtain the home feed of subscribed boards.
+type FeedIn struct {
+	BoardsStr   string // Optional, comma-separated public keys of boards.
+	Boards      []cipher.PubKey
+	TypesStr    string // Optional, comma-separated (thread, post).
+	Types       []object.ContentType
+	AuthorsStr  string // Optional, comma-separated public keys of creators.
+	Authors     []string
+	CursorStr   string // Optional, obtained from 'next_cursor' of previous page.
+	Cursor      *state.FeedCursor
+	PageSizeStr string // Optional.
+	PageSize    int
+}
+
+func (a *FeedIn) Process() error {
+	var e error
+	a.Boards = nil
+	for _, v := range splitList(a.BoardsStr) {
+		pk, e := tag.GetPubKey(v)
+		if e != nil {
+			return ErrProcess(e, "board public key")
+		}
+		a.Boards = append(a.Boards, pk)
+	}
+	if a.Types, e = getContentTypes(a.TypesStr,
+		object.V5ThreadType, object.V5PostType,
+	); e != nil {
+		return ErrProcess(e, "content types")
+	}
+	a.Authors = nil
+	for _, v := range splitList(a.AuthorsStr) {
+		if _, e := tag.GetPubKey(v); e != nil {
+			return ErrProcess(e, "author public key")
+		}
+		a.Authors = append(a.Authors, v)
+	}
+	a.Cursor = nil
+	if a.CursorStr != "" {
+		if a.Cursor, e = state.GetFeedCursor(a.CursorStr); e != nil {
+			return ErrProcess(e, "cursor")
+		}
+	}
+	a.PageSize = state.DefaultFeedPageSize
+	if a.PageSizeStr != "" {
+		size, e := tag.GetUint(a.PageSizeStr)
+		if e != nil || size == 0 {
+			return ErrProcess(e, "page size")
+		}
+		a.PageSize = int(size)
+	}
+	return nil
+}
+
+// CheckBoardIn represents the input required to check the consistency of a board's views.
+type CheckBoardIn struct {
+	BoardPubKeyStr string
+	BoardPubKey    cipher.PubKey
+	RepairStr      string
+	Repair         bool
+}
+
+func (a *CheckBoardIn) Process() error {
+	var e error
+	if a.BoardPubKey, e = tag.GetPubKey(a.BoardPubKeyStr); e != nil {
+		return ErrProcess(e, "board public key")
+	}
+	if a.RepairStr != "" {
+		if a.Repair, e = strconv.ParseBool(a.RepairStr); e != nil {
+			return ErrProcess(e, "repair")
+		}
+	}
+	return nil
+}
+
+// PublishStatsIn represents the input required to obtain publishing metrics of a board.
+type PublishStatsIn struct {
+	BoardPubKeyStr string
+	BoardPubKey    cipher.PubKey
+}
+
+func (a *PublishStatsIn) Process() error {
+	var e error
+	if a.BoardPubKey, e = tag.GetPubKey(a.BoardPubKeyStr); e != nil {
+		return ErrProcess(e, "board public key")
+	}
+	return nil
+}
+
+// StreamIn represents the input required to stream changes of compiled boards.
+type StreamIn struct {
+	BoardPubKeyStr string // Optional, leave empty to stream all boards.
+	BoardPubKey    cipher.PubKey
+	ThreadHashStr  string // Optional.
+	TypesStr       string // Optional, comma-separated event types.
+	SinceSeqStr    string // Optional, root sequence to resume from (requires board).
+	SinceSeq       uint64
+	Resume         bool
+	Filter         state.EventFilter
+}
+
+func (a *StreamIn) Process() error {
+	var e error
+	if a.BoardPubKeyStr != "" {
+		if a.BoardPubKey, e = tag.GetPubKey(a.BoardPubKeyStr); e != nil {
+			return ErrProcess(e, "board public key")
+		}
+		a.Filter.Boards = []string{a.BoardPubKey.Hex()}
+		// Board updates mark resumable root sequences, hence always pass.
+		a.Filter.Updates = true
+	}
+	if a.ThreadHashStr != "" {
+		tHash, e := tag.GetHash(a.ThreadHashStr)
+		if e != nil {
+			return ErrProcess(e, "thread hash")
+		}
+		a.Filter.Threads = []string{tHash.Hex()}
+	}
+	if a.TypesStr != "" {
+		for _, v := range strings.Split(a.TypesStr, ",") {
+			t, e := state.GetEventType(strings.TrimSpace(v))
+			if e != nil {
+				return ErrProcess(e, "event types")
+			}
+			a.Filter.Types = append(a.Filter.Types, t)
+		}
+	}
+	if a.SinceSeqStr != "" {
+		if a.BoardPubKeyStr == "" {
+			return boo.New(boo.InvalidInput,
+				"board public key is required to resume from a root sequence")
+		}
+		if a.SinceSeq, e = strconv.ParseUint(a.SinceSeqStr, 10, 64); e != nil {
+			return ErrProcess(e, "since seq")
+		}
+		a.Resume = true
+		a.Filter.MinSeq = a.SinceSeq + 1
+	}
+	return nil
+}
+
 /*
 	<<< HELPER FUNCTIONS >>>
 */
@@ -470,3 +1052,32 @@ func ErrProcess(e error, what string) error {
 		return boo.WrapType(e, boo.InvalidInput, msg)
 	}
 }
+
+// splitList splits a comma-separated list, ignoring empty elements.
+func splitList(v string) []string {
+	var out []string
+	for _, elem := range strings.Split(v, ",") {
+		if elem = strings.TrimSpace(elem); elem != "" {
+			out = append(out, elem)
+		}
+	}
+	return out
+}
+
+// getContentTypes obtains content types from a comma-separated list of type names
+// without version prefix (e.g. 'thread,post').
+func getContentTypes(v string, allowed ...object.ContentType) ([]object.ContentType, error) {
+	var out []object.ContentType
+	for _, elem := range splitList(v) {
+		t := object.ContentType("5," + elem)
+		ok := false
+		for _, at := range allowed {
+			ok = ok || t == at
+		}
+		if !ok {
+			return nil, boo.Newf(boo.InvalidInput, "invalid content type '%s'", elem)
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
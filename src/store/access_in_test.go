@@ -0,0 +1,43 @@
+package store
+
+import (
+	"github.com/skycoin/bbs/src/store/object"
+	"github.com/skycoin/skycoin/src/cipher"
+	"strconv"
+	"testing"
+)
+
+func TestNewBoardIn_Process(t *testing.T) {
+	for _, n := range []int{0, object.MaxPoWBits, object.MaxPoWBits + 1} {
+		in := &NewBoardIn{
+			Name:    "Board",
+			Body:    "A board with a proof-of-work difficulty.",
+			Seed:    "a",
+			PoWBits: n,
+		}
+		e := in.Process(nil)
+		if n > object.MaxPoWBits && e == nil {
+			t.Errorf("expected difficulty of %d bits to be rejected", n)
+		}
+		if n <= object.MaxPoWBits && e != nil {
+			t.Errorf("expected difficulty of %d bits to be accepted, got: %v", n, e)
+		}
+	}
+}
+
+func TestBoardPoWIn_Process(t *testing.T) {
+	pk, _ := cipher.GenerateDeterministicKeyPair([]byte("a"))
+	for _, n := range []int{object.MaxPoWBits, object.MaxPoWBits + 1} {
+		in := &BoardPoWIn{
+			BoardPubKeyStr: pk.Hex(),
+			PoWBitsStr:     strconv.Itoa(n),
+		}
+		e := in.Process()
+		if n > object.MaxPoWBits && e == nil {
+			t.Errorf("expected difficulty of %d bits to be rejected", n)
+		}
+		if n <= object.MaxPoWBits && e != nil {
+			t.Errorf("expected difficulty of %d bits to be accepted, got: %v", n, e)
+		}
+	}
+}
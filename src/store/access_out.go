@@ -74,6 +74,42 @@ func getBoardsOut(_ context.Context, m, r []interface{}) *BoardsOut {
 	}
 }
 
+type ForkBoardOut struct {
+	Fork   string           `json:"fork_public_key"`
+	Replay *state.ReplayOut `json:"replay"`
+	*BoardsOut
+}
+
+func getForkBoardOut(pk cipher.PubKey, replay *state.ReplayOut, boards *BoardsOut) *ForkBoardOut {
+	return &ForkBoardOut{
+		Fork:      pk.Hex(),
+		Replay:    replay,
+		BoardsOut: boards,
+	}
+}
+
+type OutboxOut struct {
+	Pending  int                  `json:"pending"`
+	Accepted int                  `json:"accepted"`
+	Rejected int                  `json:"rejected"`
+	Items    []*object.OutboxItem `json:"items"`
+}
+
+func getOutboxOut(items []*object.OutboxItem) *OutboxOut {
+	out := &OutboxOut{Items: items}
+	for _, item := range items {
+		switch item.Status {
+		case object.OutboxPending:
+			out.Pending++
+		case object.OutboxAccepted:
+			out.Accepted++
+		case object.OutboxRejected:
+			out.Rejected++
+		}
+	}
+	return out
+}
+
 type BoardOut struct {
 	Board interface{} `json:"board"`
 }
@@ -95,3 +131,38 @@ func getExportBoardOut(path string, pages *object.PagesJSON) *ExportBoardOut {
 		Board:    pages.BoardPage.Board.ToRep(),
 	}
 }
+
+type SearchOut struct {
+	Query      string                `json:"query"`
+	TotalCount int                   `json:"total_count"`
+	StartIndex int                   `json:"start_index"`
+	Results    []*state.SearchResult `json:"results"`
+}
+
+func getSearchOut(in *SearchIn, results []*state.SearchResult) *SearchOut {
+	start, end := in.Bounds(len(results))
+	return &SearchOut{
+		Query:      in.Query,
+		TotalCount: len(results),
+		StartIndex: start,
+		Results:    results[start:end],
+	}
+}
+
+type FeedOut struct {
+	Items      []*state.FeedItem `json:"items"`
+	NextCursor string            `json:"next_cursor,omitempty"` // Empty if there are no more items.
+}
+
+func getFeedOut(in *FeedIn, items []*state.FeedItem) *FeedOut {
+	state.SortFeedItems(items)
+	out := &FeedOut{Items: items}
+	if len(items) > in.PageSize {
+		out.Items = items[:in.PageSize]
+		out.NextCursor = out.Items[in.PageSize-1].Cursor().String()
+	}
+	if out.Items == nil {
+		out.Items = make([]*state.FeedItem, 0)
+	}
+	return out
+}
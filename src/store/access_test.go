@@ -0,0 +1,45 @@
+package store
+
+import (
+	"github.com/skycoin/bbs/src/misc/boo"
+	"github.com/skycoin/bbs/src/store/state"
+	"github.com/skycoin/skycoin/src/cipher"
+	"testing"
+)
+
+func TestResolveBoards(t *testing.T) {
+	var (
+		oldPK, _     = cipher.GenerateDeterministicKeyPair([]byte("a"))
+		newPK, _     = cipher.GenerateDeterministicKeyPair([]byte("b"))
+		otherPK, _   = cipher.GenerateDeterministicKeyPair([]byte("c"))
+		missingPK, _ = cipher.GenerateDeterministicKeyPair([]byte("d"))
+		successor    = new(state.BoardInstance)
+		other        = new(state.BoardInstance)
+	)
+	// The old board is transferred to the new board.
+	resolve := func(pk cipher.PubKey) (*state.BoardInstance, error) {
+		switch pk {
+		case oldPK, newPK:
+			return successor, nil
+		case otherPK:
+			return other, nil
+		default:
+			return nil, boo.New(boo.NotFound, "board not found")
+		}
+	}
+	pks := []cipher.PubKey{oldPK, otherPK, missingPK, newPK}
+
+	bis, e := resolveBoards(pks, resolve, false)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(bis) != 2 || bis[0] != successor || bis[1] != other {
+		t.Errorf("expected each board instance once, got %v", bis)
+	}
+
+	t.Run("strict", func(t *testing.T) {
+		if _, e := resolveBoards(pks, resolve, true); boo.Type(e) != boo.NotFound {
+			t.Error("expected missing board to fail, got:", e)
+		}
+	})
+}
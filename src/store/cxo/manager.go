@@ -30,8 +30,10 @@ const (
 	LogPrefix                = "CXO"
 	SubDir                   = "cxo_v5"
 	FileName                 = "bbs.json"
+	OutboxFileName           = "outbox.json"
 	ExportSubDir             = "exports"
 	ExportFileExt            = ".export"
+	SnapshotSubDir           = "snapshots"
 	BashAutoCompleteFileName = "bash_autocomplete"
 	RetryDuration            = time.Second * 5
 )
@@ -54,6 +56,7 @@ type Manager struct {
 	c        *ManagerConfig
 	l        *log2.Logger
 	file     *object.CXOFileManager
+	outbox   *object.Outbox
 	node     *node.Node
 	compiler *state.Compiler
 	relay    *accord.Relay
@@ -70,11 +73,23 @@ func NewManager(config *ManagerConfig, compilerConfig *state.CompilerConfig) *Ma
 		file: object.NewCXOFileManager(&object.CXOFileManagerConfig{
 			Memory: config.Memory,
 		}),
+		outbox: object.NewOutbox(&object.CXOFileManagerConfig{
+			Memory: config.Memory,
+		}),
 		relay:    accord.NewRelay(),
 		newRoots: make(chan state.RootWrap, 10),
 		quit:     make(chan struct{}),
 	}
 
+	// Snapshots of compiled boards are only kept when not in memory mode.
+	// The configuration is copied, so that the caller's is not modified.
+	if !*config.Memory && compilerConfig.SnapshotDir == nil {
+		snapshotDir := path.Join(*config.Config, SnapshotSubDir)
+		compilerConfigCopy := *compilerConfig
+		compilerConfigCopy.SnapshotDir = &snapshotDir
+		compilerConfig = &compilerConfigCopy
+	}
+
 	// Prepare CXO node.
 	if e := manager.prepareNode(); e != nil {
 		manager.l.Panicln("failed to start CXO manager:", e)
@@ -82,6 +97,9 @@ func NewManager(config *ManagerConfig, compilerConfig *state.CompilerConfig) *Ma
 
 	// Prepare CXO compiler.
 	manager.compiler = state.NewCompiler(compilerConfig, manager.file, manager.newRoots, manager.node)
+	transfers := manager.compiler.Events().Subscribe(&state.EventFilter{
+		Types: []state.EventType{state.EventBoardDeleted},
+	}, 0)
 
 	// Prepare messenger relay.
 	if e := manager.relay.Open(manager.compiler); e != nil {
@@ -111,6 +129,8 @@ func NewManager(config *ManagerConfig, compilerConfig *state.CompilerConfig) *Ma
 
 	go manager.retryLoop()
 	go manager.relayLoop()
+	go manager.transferLoop(transfers)
+	go manager.outboxLoop()
 	return manager
 }
 
@@ -225,6 +245,9 @@ func (m *Manager) prepareFile() error {
 	if e := m.file.Load(m.filePath()); e != nil {
 		return e
 	}
+	if e := m.outbox.Load(m.outboxPath()); e != nil {
+		return e
+	}
 
 	// Ensure messenger addresses and subscriptions.
 	for _, address := range m.c.EnforcedMessengerAddresses {
@@ -266,6 +289,10 @@ func (m *Manager) filePath() string {
 	return path.Join(*m.c.Config, SubDir, FileName)
 }
 
+func (m *Manager) outboxPath() string {
+	return path.Join(*m.c.Config, SubDir, OutboxFileName)
+}
+
 func (m *Manager) exportPath(name string) string {
 	return path.Join(*m.c.Config, ExportSubDir, name+ExportFileExt)
 }
@@ -281,9 +308,11 @@ func (m *Manager) retryLoop() {
 		select {
 		case <-m.quit:
 			m.file.Save(m.filePath())
+			m.outbox.Save(m.outboxPath())
 			return
 		case <-ticker.C:
 			m.file.Save(m.filePath())
+			m.outbox.Save(m.outboxPath())
 		}
 	}
 }
@@ -326,6 +355,51 @@ func (m *Manager) relayLoop() {
 	}
 }
 
+// transferLoop follows remote boards that are transferred to new keypairs,
+// by subscribing to their successors.
+func (m *Manager) transferLoop(sub *state.EventSubscription) {
+	m.wg.Add(1)
+	defer m.wg.Done()
+	defer sub.Close()
+
+	for {
+		select {
+		case <-m.quit:
+			return
+		case event, ok := <-sub.C():
+			if !ok {
+				return
+			}
+			m.followTransfer(event)
+		}
+	}
+}
+
+func (m *Manager) followTransfer(event *state.Event) {
+	if event.Deletion == nil || event.Deletion.Transfer == nil {
+		return
+	}
+	bpk, e := tag.GetPubKey(event.BoardPubKey)
+	if e != nil || !m.file.HasRemoteSub(bpk) {
+		return
+	}
+	successor, e := event.Deletion.Transfer.Verify(bpk)
+	if e != nil {
+		m.l.Printf("FAILED: board transfer: board(%s), error: %v", event.BoardPubKey, e)
+		return
+	}
+	if m.file.HasRemoteSub(successor) || m.file.HasMasterSub(successor) {
+		return
+	}
+	if e := m.SubscribeRemote(successor); e != nil {
+		m.l.Printf("FAILED: board transfer: board(%s) successor(%s), error: %v",
+			event.BoardPubKey, successor.Hex(), e)
+		return
+	}
+	m.l.Printf("SUCCESS: board transfer: board(%s) successor(%s)",
+		event.BoardPubKey, successor.Hex())
+}
+
 /*
 	<<< MESSENGER >>>
 */
@@ -367,6 +441,9 @@ func (m *Manager) GetMessengers() []*object.MessengerConnection {
 	return out
 }
 
+// SubmitToRemote submits content to a remote board through messenger servers.
+// If no response is obtained from the remote board, the submission is kept in
+// the outbox to be retried.
 func (m *Manager) SubmitToRemote(
 	ctx context.Context, subKeys []*object.MessengerSubKeyTransport, transport *object.Transport,
 ) (
@@ -374,17 +451,36 @@ func (m *Manager) SubmitToRemote(
 ) {
 	m.l.Println("attempting to submit to remote...")
 
-	// Ensure that we can submit.
-	if len(subKeys) == 0 {
-		return 0, boo.New(boo.NotAllowed, "no submission keys are provided")
-	}
-
 	// Obtain submission.
 	submission := &accord.Submission{
 		Raw: transport.Content.Body,
 		Sig: transport.Header.GetSig(),
 	}
 
+	res, e := m.submitToRemote(ctx, subKeys, submission)
+	if e != nil {
+		if len(subKeys) == 0 {
+			// Retrying can never succeed without submission keys.
+			return 0, e
+		}
+		m.outbox.Add(transport.GetOfBoard(), submission.Raw, submission.Sig, subKeys, e)
+		return 0, boo.WrapType(e, boo.Type(e),
+			"submission is kept in outbox to be retried")
+	}
+	return res.Seq, res.Error()
+}
+
+// submitToRemote attempts to obtain a response of the remote board for the submission.
+func (m *Manager) submitToRemote(
+	ctx context.Context, subKeys []*object.MessengerSubKeyTransport, submission *accord.Submission,
+) (
+	*accord.SubmissionResponse, error,
+) {
+	// Ensure that we can submit.
+	if len(subKeys) == 0 {
+		return nil, boo.New(boo.NotAllowed, "no submission keys are provided")
+	}
+
 	// See if we are connected to any of the submission keys.
 	m.l.Println("\t- looping through provided submission keys...")
 
@@ -399,13 +495,13 @@ func (m *Manager) SubmitToRemote(
 		}
 
 		// Attempt to submit.
-		goal, e := m.relay.SubmitToRemote(ctx, subKey.PubKey, submission)
+		res, e := m.relay.Submit(ctx, subKey.PubKey, submission)
 		if e != nil {
-			m.l.Println("\t\t\t- Failed to submit to remote, error: %v", e)
+			m.l.Printf("\t\t\t- Failed to submit to remote, error: %v", e)
 			m.l.Println("\t\t\t (SKIPPING)")
 			continue
 		}
-		return goal, nil
+		return res, nil
 	}
 
 	// Attempt manual connections.
@@ -413,14 +509,14 @@ func (m *Manager) SubmitToRemote(
 		if _, e := m.relay.Connect(subKey.Address); e != nil {
 			continue
 		}
-		goal, e := m.relay.SubmitToRemote(ctx, subKey.PubKey, submission)
+		res, e := m.relay.Submit(ctx, subKey.PubKey, submission)
 		if e != nil {
 			continue
 		}
-		return goal, nil
+		return res, nil
 	}
 
-	return 0, boo.New(boo.NotFound, "a valid connection to messenger server is not found")
+	return nil, boo.New(boo.NotFound, "a valid connection to messenger server is not found")
 }
 
 func (m *Manager) GetAvailableBoards() []cipher.PubKey {
@@ -580,8 +676,20 @@ func (m *Manager) unsubscribeNode(bpk cipher.PubKey) {
 	<<< CONTENT >>>
 */
 
+// Events obtains the event bus in which changes of compiled boards are broadcast to.
+func (m *Manager) Events() *state.EventBus {
+	return m.compiler.Events()
+}
+
+// GetCompilerStats obtains metrics of the compiler and it's per-board workers.
+func (m *Manager) GetCompilerStats() *state.CompilerStats {
+	return m.compiler.GetStats()
+}
+
+// GetBoardInstance obtains the board instance of given public key.
+// Boards that are transferred to new keypairs are redirected to their successors.
 func (m *Manager) GetBoardInstance(bpk cipher.PubKey) (*state.BoardInstance, error) {
-	return m.compiler.GetBoard(bpk)
+	return m.compiler.ResolveBoard(bpk)
 }
 
 func (m *Manager) GetBoards(ctx context.Context) ([]interface{}, []interface{}, error) {
@@ -640,13 +748,145 @@ func (m *Manager) NewBoard(content *object.Content, pk cipher.PubKey, sk cipher.
 	<<< ADMIN >>>
 */
 
+// PublishBoardDeletion marks a master board as deleted in a final published root.
+func (m *Manager) PublishBoardDeletion(pk cipher.PubKey, notice *object.BoardDeletion) error {
+	if m.file.HasMasterSub(pk) == false {
+		return boo.Newf(boo.NotFound,
+			"master board of public key '%s' not found in cxo file", pk.Hex()[:5]+"...")
+	}
+	bi, e := m.compiler.GetBoard(pk)
+	if e != nil {
+		return e
+	}
+	return bi.PublishDeletion(notice)
+}
+
+// SetBoardPoW sets the proof-of-work difficulty that a master board advertises,
+// which submissions to the board are required to satisfy.
+func (m *Manager) SetBoardPoW(pk cipher.PubKey, n int) error {
+	if m.file.HasMasterSub(pk) == false {
+		return boo.Newf(boo.NotFound,
+			"master board of public key '%s' not found in cxo file", pk.Hex()[:5]+"...")
+	}
+	bi, e := m.compiler.GetBoard(pk)
+	if e != nil {
+		return e
+	}
+	if _, e := bi.SetPoWBits(n); e != nil {
+		return e
+	}
+	return bi.PublishChanges()
+}
+
+// TransferBoard migrates a master board to a new keypair, carrying over it's content.
+// The old board publishes a transfer record signed by it's key, so that subscribers
+// of the old board follow the successor.
+func (m *Manager) TransferBoard(ctx context.Context, pk, newPK cipher.PubKey, newSK cipher.SecKey) error {
+	sk, ok := m.file.GetMasterSubSecKey(pk)
+	if !ok {
+		return boo.Newf(boo.NotFound,
+			"master board of public key '%s' not found in cxo file", pk.Hex()[:5]+"...")
+	}
+	if m.file.HasMasterSub(newPK) || m.file.HasRemoteSub(newPK) {
+		return boo.Newf(boo.AlreadyExists,
+			"board of public key '%s' already exists", newPK.Hex()[:5]+"...")
+	}
+	bi, e := m.compiler.GetBoard(pk)
+	if e != nil {
+		return e
+	}
+	pages, e := bi.Export(pk, sk)
+	if e != nil {
+		return e
+	}
+	if e := pages.CarryOver(newPK, newSK); e != nil {
+		return e
+	}
+	if e := m.ImportBoard(ctx, pages); e != nil {
+		return e
+	}
+	ts := time.Now().UnixNano()
+	return bi.PublishDeletion(&object.BoardDeletion{
+		Reason:    "board is transferred to a new keypair",
+		Successor: newPK.Hex(),
+		TS:        ts,
+		Transfer:  object.NewBoardTransfer(pk, sk, newPK, ts),
+	})
+}
+
+// ForkBoard creates master board 'newPK' from remote board 'pk', by replaying the
+// signed submissions of the remote board. The fork's metadata records it's origin,
+// and submission keys are of this node's messengers.
+func (m *Manager) ForkBoard(ctx context.Context, pk, newPK cipher.PubKey, newSK cipher.SecKey, name string) (*state.ReplayOut, error) {
+	if m.file.HasRemoteSub(pk) == false {
+		return nil, boo.Newf(boo.NotFound,
+			"remote board of public key '%s' not found in cxo file", pk.Hex()[:5]+"...")
+	}
+	if m.file.HasMasterSub(newPK) || m.file.HasRemoteSub(newPK) {
+		return nil, boo.Newf(boo.AlreadyExists,
+			"board of public key '%s' already exists", newPK.Hex()[:5]+"...")
+	}
+	origin, e := m.compiler.GetBoard(pk)
+	if e != nil {
+		return nil, e
+	}
+	originView, e := origin.Viewer().GetBoard()
+	if e != nil {
+		return nil, e
+	}
+	originBody, ok := originView.Body.(*object.Body)
+	if !ok {
+		return nil, boo.New(boo.Internal, "failed to obtain body of remote board")
+	}
+
+	body := &object.Body{
+		Type:    object.V5BoardType,
+		TS:      time.Now().UnixNano(),
+		Name:    originBody.Name,
+		Body:    originBody.Body,
+		Tags:    originBody.Tags,
+		Lineage: append(append([]string{}, originBody.Lineage...), pk.Hex()),
+		ForkOf:  pk.Hex(),
+	}
+	if name != "" {
+		body.Name = name
+	}
+	subKeyTrans := m.relay.SubmissionKeys()
+	body.SubKeys = make([]object.MessengerSubKey, len(subKeyTrans))
+	for i, subKey := range subKeyTrans {
+		body.SubKeys[i] = subKey.ToMessengerSubKey()
+	}
+	content := new(object.Content)
+	content.SetHeader(&object.ContentHeaderData{})
+	content.SetBody(body)
+
+	if e := m.NewBoard(content, newPK, newSK); e != nil {
+		return nil, e
+	}
+	fork, e := m.compiler.GetBoard(newPK)
+	if e != nil {
+		return nil, e
+	}
+	out, e := fork.Replay(origin)
+	if e != nil {
+		return nil, e
+	}
+	if out.Replayed == 0 {
+		return out, nil
+	}
+	if e := fork.PublishChanges(); e != nil {
+		return nil, e
+	}
+	return out, fork.WaitSeq(ctx, out.Goal)
+}
+
 /*
 	<<< IMPORT / EXPORT >>>
 */
 
 func (m *Manager) ExportBoard(pk cipher.PubKey, path string) (*object.PagesJSON, error) {
 	sk, _ := m.file.GetMasterSubSecKey(pk)
-	bi, e := m.GetBoardInstance(pk)
+	bi, e := m.compiler.GetBoard(pk)
 	if e != nil {
 		return nil, e
 	}
@@ -677,7 +917,7 @@ func (m *Manager) ImportBoard(ctx context.Context, in *object.PagesJSON) error {
 			return e
 		}
 	}
-	bi, e := m.GetBoardInstance(pk)
+	bi, e := m.compiler.GetBoard(pk)
 	if e != nil {
 		return e
 	}
@@ -0,0 +1,96 @@
+package cxo
+
+import (
+	"context"
+	"github.com/skycoin/bbs/src/accord"
+	"github.com/skycoin/bbs/src/misc/boo"
+	"github.com/skycoin/bbs/src/misc/tag"
+	"github.com/skycoin/bbs/src/store/object"
+	"time"
+)
+
+const (
+	OutboxCheckDuration = time.Second      // Interval in which due submissions are retried.
+	OutboxSubmitTimeout = time.Second * 30 // Max time to wait for a response of a retried submission.
+)
+
+// GetOutbox obtains the submissions to remote boards that are kept in the outbox.
+func (m *Manager) GetOutbox() []*object.OutboxItem {
+	return m.outbox.List()
+}
+
+func (m *Manager) outboxLoop() {
+	m.wg.Add(1)
+	defer m.wg.Done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-m.quit
+		cancel()
+	}()
+
+	ticker := time.NewTicker(OutboxCheckDuration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.quit:
+			return
+		case now := <-ticker.C:
+			for _, item := range m.outbox.Due(now) {
+				m.retrySubmission(ctx, item)
+				if ctx.Err() != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+// retrySubmission resubmits a submission of the outbox.
+// The submission is finished when the remote board accepts or permanently
+// rejects it, otherwise it is retried later.
+func (m *Manager) retrySubmission(ctx context.Context, item *object.OutboxItem) {
+	subKeys := item.GetSubKeys()
+	if bpk, e := tag.GetPubKey(item.BoardPubKey); e == nil {
+		if bi, e := m.GetBoardInstance(bpk); e == nil {
+			if current := bi.GetSubmissionKeys(); len(current) > 0 {
+				subKeys = current
+			}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, OutboxSubmitTimeout)
+	defer cancel()
+
+	var seq uint64
+	res, e := m.submitToRemote(ctx, subKeys, &accord.Submission{
+		Raw: []byte(item.Body),
+		Sig: item.GetSig(),
+	})
+	if e == nil {
+		seq, e = res.Seq, res.Error()
+	}
+	switch {
+	case e == nil, boo.Type(e) == boo.AlreadyExists:
+		// Submission was accepted (possibly in a previous attempt).
+		m.outbox.Finish(item.Hash, seq, nil)
+	case isTransientError(e):
+		m.outbox.Retry(item.Hash, e)
+	default:
+		m.outbox.Finish(item.Hash, seq, e)
+	}
+}
+
+// isTransientError determines whether a failed submission may succeed when retried,
+// which is when the remote board cannot be reached or fails to process it.
+// Submissions that are malformed or not allowed by the remote board will never succeed.
+func isTransientError(e error) bool {
+	switch boo.Type(e) {
+	case boo.Unknown, boo.Internal, boo.NotFound:
+		return true
+	default:
+		return false
+	}
+}
@@ -433,7 +433,7 @@ func (m *CXOFileManager) load(path string) error {
 	// Range messenger addresses.
 	for i, address := range fileData.MessengerAddresses {
 		if e := tag.CheckAddress(address); e != nil {
-			return boo.WrapType(e, boo.InvalidRead,
+			return boo.WrapTypef(e, boo.InvalidRead,
 				"invalid address in file at messenger_addresses[%d]", i)
 		}
 		m.messengers.Append(address, cipher.PubKey{})
@@ -442,7 +442,7 @@ func (m *CXOFileManager) load(path string) error {
 	// Range connections.
 	for i, address := range fileData.Connections {
 		if e := tag.CheckAddress(address); e != nil {
-			return boo.WrapType(e, boo.InvalidRead,
+			return boo.WrapTypef(e, boo.InvalidRead,
 				"invalid address in file at connections[%d]", i)
 		}
 		m.connections.Append(address, false)
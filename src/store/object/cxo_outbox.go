@@ -0,0 +1,293 @@
+package object
+
+import (
+	"github.com/skycoin/bbs/src/misc/boo"
+	"github.com/skycoin/bbs/src/misc/inform"
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/util/file"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	outboxLogPrefix = "OUTBOX"
+
+	OutboxMinBackoff  = time.Second * 5  // Delay before first retry.
+	OutboxMaxBackoff  = time.Minute * 10 // Max delay between retries.
+	OutboxHistorySize = 100              // Max number of finished submissions kept.
+	OutboxMaxAttempts = 200              // Max attempts before submissions are rejected (over a day).
+)
+
+// OutboxStatus represents the status of a submission in the outbox.
+type OutboxStatus string
+
+const (
+	OutboxPending  = OutboxStatus("pending")  // Waiting to be (re)submitted.
+	OutboxAccepted = OutboxStatus("accepted") // Accepted by the remote board.
+	OutboxRejected = OutboxStatus("rejected") // Permanently rejected by the remote board.
+)
+
+// OutboxItem is a signed submission to a remote board that is kept until the
+// remote board responds.
+type OutboxItem struct {
+	Hash        string            `json:"hash"`
+	BoardPubKey string            `json:"board_public_key"`
+	Body        string            `json:"body"` // Raw body of submission.
+	Sig         string            `json:"sig"`
+	SubKeys     []MessengerSubKey `json:"submission_keys"` // Submission keys of board at time of submission.
+	Status      OutboxStatus      `json:"status"`
+	Attempts    int               `json:"attempts"`
+	LastError   string            `json:"last_error,omitempty"`
+	Seq         uint64            `json:"seq,omitempty"` // Root sequence the submission is accepted in.
+	Created     int64             `json:"created"`
+	LastAttempt int64             `json:"last_attempt,omitempty"`
+	NextAttempt int64             `json:"next_attempt,omitempty"`
+}
+
+// GetSig obtains the signature of the submission.
+func (i *OutboxItem) GetSig() cipher.Sig {
+	sig, _ := cipher.SigFromHex(i.Sig)
+	return sig
+}
+
+// GetSubKeys obtains the stored submission keys of the board.
+func (i *OutboxItem) GetSubKeys() []*MessengerSubKeyTransport {
+	out := make([]*MessengerSubKeyTransport, 0, len(i.SubKeys))
+	for _, subKey := range i.SubKeys {
+		if t, e := subKey.ToTransport(); e == nil {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// OutboxFile is the saved form of the outbox.
+type OutboxFile struct {
+	Items []*OutboxItem `json:"items"`
+}
+
+// Outbox keeps submissions to remote boards until they are accepted or
+// permanently rejected, so that they can be retried with backoff.
+type Outbox struct {
+	c          *CXOFileManagerConfig
+	l          *log.Logger
+	mux        sync.Mutex
+	hasChanges bool
+	items      []*OutboxItem // In order of creation.
+}
+
+// NewOutbox creates a new outbox. Memory mode is as of the file manager configuration.
+func NewOutbox(config *CXOFileManagerConfig) *Outbox {
+	return &Outbox{
+		c: config,
+		l: inform.NewLogger(true, os.Stdout, outboxLogPrefix),
+	}
+}
+
+// Load loads the outbox from file (if not in memory mode).
+func (o *Outbox) Load(path string) error {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+	if *o.c.Memory {
+		return nil
+	}
+	var fileData OutboxFile
+	if e := file.LoadJSON(path, &fileData); e != nil {
+		if os.IsNotExist(e) {
+			return nil
+		}
+		return boo.WrapTypef(e, boo.InvalidRead,
+			"failed to read outbox file from '%s'", path)
+	}
+	o.items = fileData.Items
+	return nil
+}
+
+// Save saves the outbox to file (if not in memory mode and there are changes).
+func (o *Outbox) Save(path string) error {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+	if *o.c.Memory || !o.hasChanges {
+		return nil
+	}
+	o.hasChanges = false
+	if e := file.SaveJSON(path, &OutboxFile{Items: o.items}, os.FileMode(0600)); e != nil {
+		return boo.WrapTypef(e, boo.Internal,
+			"failed to save outbox file to '%s'", path)
+	}
+	return nil
+}
+
+// Add queues a submission for retry, after it failed to reach the remote board.
+func (o *Outbox) Add(bpk cipher.PubKey, body []byte, sig cipher.Sig, subKeys []*MessengerSubKeyTransport, reason error) *OutboxItem {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	hash := cipher.SumSHA256(body).Hex()
+	if item := o.get(hash); item != nil && item.Status == OutboxPending {
+		return item
+	}
+	now := time.Now()
+	item := &OutboxItem{
+		Hash:        hash,
+		BoardPubKey: bpk.Hex(),
+		Body:        string(body),
+		Sig:         sig.Hex(),
+		SubKeys:     make([]MessengerSubKey, len(subKeys)),
+		Status:      OutboxPending,
+		Attempts:    1,
+		Created:     now.UnixNano(),
+		LastAttempt: now.UnixNano(),
+		NextAttempt: now.Add(OutboxMinBackoff).UnixNano(),
+	}
+	for i, subKey := range subKeys {
+		item.SubKeys[i] = subKey.ToMessengerSubKey()
+	}
+	if reason != nil {
+		item.LastError = reason.Error()
+	}
+	o.remove(hash)
+	o.items = append(o.items, item)
+	o.hasChanges = true
+	o.l.Printf("queued submission '%s' of board '%s'", hash, item.BoardPubKey)
+	return item
+}
+
+// Due obtains copies of pending submissions that are due for retry.
+func (o *Outbox) Due(now time.Time) []*OutboxItem {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	var out []*OutboxItem
+	for _, item := range o.items {
+		if item.Status == OutboxPending && item.NextAttempt <= now.UnixNano() {
+			v := *item
+			out = append(out, &v)
+		}
+	}
+	return out
+}
+
+// Retry records a failed attempt that is to be retried, doubling the backoff.
+// Submissions are rejected once they reach the max number of attempts.
+func (o *Outbox) Retry(hash string, reason error) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	item := o.get(hash)
+	if item == nil || item.Status != OutboxPending {
+		return
+	}
+	now := time.Now()
+	item.Attempts++
+	item.LastAttempt = now.UnixNano()
+	item.NextAttempt = now.Add(outboxBackoff(item.Attempts)).UnixNano()
+	if reason != nil {
+		item.LastError = reason.Error()
+	}
+	if item.Attempts >= OutboxMaxAttempts {
+		item.Status = OutboxRejected
+		item.NextAttempt = 0
+		o.prune()
+		o.l.Printf("submission '%s' of board '%s' is %s after %d attempts",
+			hash, item.BoardPubKey, item.Status, item.Attempts)
+	}
+	o.hasChanges = true
+}
+
+// Finish records the response of the remote board.
+// Submissions are rejected if 'reason' is not nil.
+func (o *Outbox) Finish(hash string, seq uint64, reason error) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	item := o.get(hash)
+	if item == nil || item.Status != OutboxPending {
+		return
+	}
+	item.Attempts++
+	item.LastAttempt = time.Now().UnixNano()
+	item.NextAttempt = 0
+	if reason != nil {
+		item.Status = OutboxRejected
+		item.LastError = reason.Error()
+	} else {
+		item.Status = OutboxAccepted
+		item.Seq = seq
+		item.LastError = ""
+	}
+	o.prune()
+	o.hasChanges = true
+	o.l.Printf("submission '%s' of board '%s' is %s", hash, item.BoardPubKey, item.Status)
+}
+
+// List obtains copies of all submissions in the outbox, in order of creation.
+func (o *Outbox) List() []*OutboxItem {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	out := make([]*OutboxItem, len(o.items))
+	for i, item := range o.items {
+		v := *item
+		out[i] = &v
+	}
+	return out
+}
+
+/*
+	<<< HELPER FUNCTIONS >>>
+*/
+
+func outboxBackoff(attempts int) time.Duration {
+	d := OutboxMinBackoff
+	for i := 1; i < attempts && d < OutboxMaxBackoff; i++ {
+		d *= 2
+	}
+	if d > OutboxMaxBackoff {
+		d = OutboxMaxBackoff
+	}
+	return d
+}
+
+// Should only be used when outbox is locked.
+func (o *Outbox) get(hash string) *OutboxItem {
+	for _, item := range o.items {
+		if item.Hash == hash {
+			return item
+		}
+	}
+	return nil
+}
+
+// Should only be used when outbox is locked.
+func (o *Outbox) remove(hash string) bool {
+	for i, item := range o.items {
+		if item.Hash == hash {
+			o.items = append(o.items[:i], o.items[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// prune removes the oldest finished submissions that exceed the history size.
+// Should only be used when outbox is locked.
+func (o *Outbox) prune() {
+	finished := 0
+	for _, item := range o.items {
+		if item.Status != OutboxPending {
+			finished++
+		}
+	}
+	out := o.items[:0]
+	for _, item := range o.items {
+		if item.Status != OutboxPending && finished > OutboxHistorySize {
+			finished--
+			continue
+		}
+		out = append(out, item)
+	}
+	o.items = out
+}
@@ -0,0 +1,194 @@
+package object
+
+import (
+	"errors"
+	"fmt"
+	"github.com/skycoin/skycoin/src/cipher"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestOutbox(memory bool) *Outbox {
+	return NewOutbox(&CXOFileManagerConfig{Memory: &memory})
+}
+
+func addTestSubmission(o *Outbox, body string) *OutboxItem {
+	bpk, _ := cipher.GenerateDeterministicKeyPair([]byte("board"))
+	return o.Add(bpk, []byte(body), cipher.Sig{}, nil, errors.New("no response"))
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	cases := []struct {
+		attempts int
+		exp      time.Duration
+	}{
+		{1, OutboxMinBackoff},
+		{2, OutboxMinBackoff * 2},
+		{3, OutboxMinBackoff * 4},
+		{7, OutboxMinBackoff * 64},
+		{8, OutboxMaxBackoff},
+		{1000, OutboxMaxBackoff},
+	}
+	for _, c := range cases {
+		if d := outboxBackoff(c.attempts); d != c.exp {
+			t.Errorf("attempts %d: expected backoff %v, got %v", c.attempts, c.exp, d)
+		}
+	}
+}
+
+func TestOutbox_Due(t *testing.T) {
+	o := newTestOutbox(true)
+	item := addTestSubmission(o, "a")
+	now := time.Unix(0, item.LastAttempt)
+
+	if due := o.Due(now); len(due) != 0 {
+		t.Fatalf("expected no due submissions before backoff, got %d", len(due))
+	}
+	if due := o.Due(now.Add(OutboxMinBackoff)); len(due) != 1 || due[0].Hash != item.Hash {
+		t.Fatalf("expected submission to be due after backoff, got %v", due)
+	}
+
+	t.Run("retry", func(t *testing.T) {
+		o.Retry(item.Hash, errors.New("still no response"))
+		due := o.Due(time.Now().Add(OutboxMinBackoff))
+		if len(due) != 0 {
+			t.Fatal("expected backoff to double after retry")
+		}
+		due = o.Due(time.Now().Add(OutboxMinBackoff * 2))
+		if len(due) != 1 || due[0].Attempts != 2 || due[0].LastError != "still no response" {
+			t.Fatalf("unexpected due submissions after retry: %v", due)
+		}
+	})
+
+	t.Run("max_attempts", func(t *testing.T) {
+		o := newTestOutbox(true)
+		item := addTestSubmission(o, "b")
+		for i := item.Attempts; i < OutboxMaxAttempts; i++ {
+			o.Retry(item.Hash, errors.New("no response"))
+		}
+		list := o.List()
+		if len(list) != 1 || list[0].Status != OutboxRejected || list[0].Attempts != OutboxMaxAttempts {
+			t.Fatalf("expected submission to be rejected after max attempts: %v", list)
+		}
+		if due := o.Due(time.Now().Add(OutboxMaxBackoff)); len(due) != 0 {
+			t.Error("expected rejected submission to not be due")
+		}
+	})
+
+	t.Run("finished", func(t *testing.T) {
+		o.Finish(item.Hash, 3, nil)
+		if due := o.Due(time.Now().Add(OutboxMaxBackoff)); len(due) != 0 {
+			t.Fatalf("expected finished submission to not be due, got %d", len(due))
+		}
+		list := o.List()
+		if len(list) != 1 || list[0].Status != OutboxAccepted || list[0].Seq != 3 {
+			t.Fatalf("unexpected submissions: %v", list)
+		}
+	})
+}
+
+func TestOutbox_Finish(t *testing.T) {
+	const extra = 5
+	o := newTestOutbox(true)
+
+	pending := addTestSubmission(o, "pending")
+	var hashes []string
+	for i := 0; i < OutboxHistorySize+extra; i++ {
+		hashes = append(hashes, addTestSubmission(o, fmt.Sprint(i)).Hash)
+	}
+	for i, hash := range hashes {
+		if i%2 == 0 {
+			o.Finish(hash, uint64(i), nil)
+		} else {
+			o.Finish(hash, 0, errors.New("rejected"))
+		}
+	}
+
+	list := o.List()
+	if len(list) != OutboxHistorySize+1 {
+		t.Fatalf("expected %d submissions, got %d", OutboxHistorySize+1, len(list))
+	}
+	if list[0].Hash != pending.Hash || list[0].Status != OutboxPending {
+		t.Error("expected pending submission to be kept")
+	}
+	for i, item := range list[1:] {
+		if exp := hashes[extra+i]; item.Hash != exp {
+			t.Fatalf("expected oldest finished submissions to be pruned: index %d has hash '%s', expected '%s'",
+				i, item.Hash, exp)
+		}
+	}
+	if list[1].Status != OutboxRejected || list[1].LastError != "rejected" {
+		t.Errorf("unexpected rejected submission: %v", list[1])
+	}
+}
+
+func TestOutbox_Add(t *testing.T) {
+	o := newTestOutbox(true)
+	item := addTestSubmission(o, "a")
+
+	t.Run("pending", func(t *testing.T) {
+		o.Retry(item.Hash, nil)
+		again := addTestSubmission(o, "a")
+		if again.Attempts != 2 || len(o.List()) != 1 {
+			t.Error("expected pending submission to be kept as is")
+		}
+	})
+
+	t.Run("finished", func(t *testing.T) {
+		o.Finish(item.Hash, 0, errors.New("rejected"))
+		again := addTestSubmission(o, "a")
+		list := o.List()
+		if len(list) != 1 {
+			t.Fatalf("expected a single submission, got %d", len(list))
+		}
+		if again.Status != OutboxPending || list[0].Status != OutboxPending || list[0].Attempts != 1 {
+			t.Errorf("expected finished submission to be queued anew: %v", list[0])
+		}
+	})
+}
+
+func TestOutbox_SaveLoad(t *testing.T) {
+	dir, e := ioutil.TempDir("", "bbs_outbox")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "outbox.json")
+
+	o := newTestOutbox(false)
+	a := addTestSubmission(o, "a")
+	b := addTestSubmission(o, "b")
+	o.Finish(b.Hash, 7, nil)
+	if e := o.Save(path); e != nil {
+		t.Fatal("failed to save outbox:", e)
+	}
+
+	loaded := newTestOutbox(false)
+	if e := loaded.Load(path); e != nil {
+		t.Fatal("failed to load outbox:", e)
+	}
+	list := loaded.List()
+	if len(list) != 2 {
+		t.Fatalf("expected 2 submissions, got %d", len(list))
+	}
+	if list[0].Hash != a.Hash || list[0].Status != OutboxPending || list[0].Body != "a" ||
+		list[0].BoardPubKey != a.BoardPubKey || list[0].NextAttempt != a.NextAttempt {
+		t.Errorf("unexpected pending submission: %v", list[0])
+	}
+	if list[1].Hash != b.Hash || list[1].Status != OutboxAccepted || list[1].Seq != 7 {
+		t.Errorf("unexpected accepted submission: %v", list[1])
+	}
+
+	t.Run("missing", func(t *testing.T) {
+		o := newTestOutbox(false)
+		if e := o.Load(filepath.Join(dir, "none.json")); e != nil {
+			t.Fatal("expected missing file to be ignored, got:", e)
+		}
+		if len(o.List()) != 0 {
+			t.Error("expected empty outbox")
+		}
+	})
+}
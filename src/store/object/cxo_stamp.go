@@ -0,0 +1,65 @@
+package object
+
+import (
+	"context"
+	"github.com/skycoin/bbs/src/misc/boo"
+	"github.com/skycoin/skycoin/src/cipher"
+	"math/bits"
+)
+
+const (
+	MaxPoWBits     = 24      // Max proof-of-work difficulty a board can require (and clients compute).
+	stampCheckRate = 1 << 12 // Number of stamps tried between checks of cancellation.
+)
+
+// CheckPoWBits checks whether 'n' is a valid proof-of-work difficulty.
+func CheckPoWBits(n int) error {
+	if n < 0 || n > MaxPoWBits {
+		return boo.Newf(boo.InvalidInput,
+			"proof-of-work difficulty of %d bits is not within range [0, %d]", n, MaxPoWBits)
+	}
+	return nil
+}
+
+// SetStamp searches for a proof-of-work stamp (hashcash-style nonce) such that the
+// hash of the body has at least 'n' leading zero bits.
+// Returns the hash and raw form of the stamped body.
+func (c *Body) SetStamp(ctx context.Context, n int) (cipher.SHA256, []byte, error) {
+	if e := CheckPoWBits(n); e != nil {
+		return cipher.SHA256{}, nil, e
+	}
+	for c.Stamp = 0; ; c.Stamp++ {
+		if hash, raw := c.ToRaw(); StampBits(hash) >= n {
+			return hash, raw, nil
+		}
+		if c.Stamp%stampCheckRate == 0 {
+			select {
+			case <-ctx.Done():
+				return cipher.SHA256{}, nil, boo.WrapType(ctx.Err(), boo.Internal,
+					"proof-of-work stamp search is cancelled")
+			default:
+			}
+		}
+	}
+}
+
+// StampBits obtains the number of leading zero bits of a content hash.
+func StampBits(hash cipher.SHA256) int {
+	out := 0
+	for _, b := range hash {
+		out += bits.LeadingZeros8(b)
+		if b != 0 {
+			break
+		}
+	}
+	return out
+}
+
+// CheckStamp checks that a content hash satisfies a proof-of-work difficulty of 'n' bits.
+func CheckStamp(hash cipher.SHA256, n int) error {
+	if got := StampBits(hash); got < n {
+		return boo.Newf(boo.NotAllowed,
+			"insufficient proof-of-work stamp, hash has %d leading zero bits while %d are required", got, n)
+	}
+	return nil
+}
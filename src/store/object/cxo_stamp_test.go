@@ -0,0 +1,36 @@
+package object
+
+import (
+	"context"
+	"github.com/skycoin/bbs/src/misc/boo"
+	"testing"
+)
+
+func TestBody_SetStamp(t *testing.T) {
+	body := &Body{Type: V5ThreadType, Name: "Stamped Thread"}
+
+	hash, raw, e := body.SetStamp(context.Background(), 8)
+	if e != nil {
+		t.Fatal("failed to set stamp:", e)
+	}
+	if e := CheckStamp(hash, 8); e != nil {
+		t.Error(e)
+	}
+	if got, _ := body.ToRaw(); got != hash || string(raw) == "" {
+		t.Error("expected returned hash to be of the stamped body")
+	}
+
+	t.Run("max", func(t *testing.T) {
+		if _, _, e := body.SetStamp(context.Background(), MaxPoWBits+1); boo.Type(e) != boo.InvalidInput {
+			t.Error("expected difficulty above max to be refused, got:", e)
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, _, e := body.SetStamp(ctx, MaxPoWBits); e == nil {
+			t.Error("expected cancelled stamp search to fail")
+		}
+	})
+}
@@ -89,6 +89,27 @@ func (pj *PagesJSON) GetSecKey() cipher.SecKey {
 	return sk
 }
 
+// CarryOver prepares exported pages to be imported into board 'pk', which the
+// content is carried over to. The exported board is added to the board's lineage,
+// so that the carried over content (which refers to the exported board) is accepted.
+func (pj *PagesJSON) CarryOver(pk cipher.PubKey, sk cipher.SecKey) error {
+	if pj.RootPage == nil || pj.BoardPage == nil || pj.BoardPage.Board == nil {
+		return boo.New(boo.InvalidRead, "exported pages are incomplete")
+	}
+	board := pj.BoardPage.Board.GetBody()
+	if !board.HasLineage(pj.PubKey) {
+		board.Lineage = append(board.Lineage, pj.PubKey)
+	}
+	pj.BoardPage.Board.SetBody(board)
+	pj.RootPage = &RootPage{
+		Typ: pj.RootPage.Typ,
+		Rev: pj.RootPage.Rev,
+		Sum: pj.BoardPage.Board.Body,
+	}
+	pj.PubKey, pj.SecKey = pk.Hex(), sk.Hex()
+	return nil
+}
+
 type GetPagesIn struct {
 	RootPage  bool
 	BoardPage bool
@@ -188,6 +209,90 @@ func GetRootPage(p *skyobject.Pack) (*RootPage, error) {
 	return rp, nil
 }
 
+func (rp *RootPage) Save(p *skyobject.Pack) error {
+	if e := p.SetRefByIndex(IndexRootPage, rp); e != nil {
+		return saveRootChildErr(e, IndexRootPage)
+	}
+	return nil
+}
+
+// BoardDeletion is the notice of a deleted board.
+// It replaces the summary of the board's final root.
+type BoardDeletion struct {
+	Reason    string         `json:"reason,omitempty"`
+	Successor string         `json:"successor,omitempty"` // Public key of the board that replaces the deleted board.
+	TS        int64          `json:"ts"`
+	Transfer  *BoardTransfer `json:"transfer,omitempty"` // Present if the board is migrated to a new keypair.
+}
+
+// BoardTransfer records the migration of a board to a new keypair.
+// It is signed by the secret key of the board it migrates from.
+type BoardTransfer struct {
+	OfBoard   string `json:"of_board"`  // Public key of the board that is migrated from.
+	Successor string `json:"successor"` // Public key of the board that is migrated to.
+	TS        int64  `json:"ts"`
+	Sig       string `json:"sig"` // Signature of transfer, signed by board that is migrated from.
+}
+
+// NewBoardTransfer creates a signed transfer record of board 'pk' to board 'successor'.
+func NewBoardTransfer(pk cipher.PubKey, sk cipher.SecKey, successor cipher.PubKey, ts int64) *BoardTransfer {
+	out := &BoardTransfer{
+		OfBoard:   pk.Hex(),
+		Successor: successor.Hex(),
+		TS:        ts,
+	}
+	out.Sig = cipher.SignHash(out.hash(), sk).Hex()
+	return out
+}
+
+func (bt *BoardTransfer) hash() cipher.SHA256 {
+	return cipher.SumSHA256(jsonMarshal(&BoardTransfer{
+		OfBoard:   bt.OfBoard,
+		Successor: bt.Successor,
+		TS:        bt.TS,
+	}))
+}
+
+// Verify checks that the transfer is of board 'pk' and is signed by it.
+// The successor's public key is returned.
+func (bt *BoardTransfer) Verify(pk cipher.PubKey) (cipher.PubKey, error) {
+	if bt.OfBoard != pk.Hex() {
+		return cipher.PubKey{}, boo.Newf(boo.NotAllowed,
+			"transfer is of board '%s', expected '%s'", bt.OfBoard, pk.Hex())
+	}
+	successor, e := tag.GetPubKey(bt.Successor)
+	if e != nil {
+		return cipher.PubKey{}, boo.WrapType(e, boo.InvalidRead,
+			"invalid successor public key of transfer")
+	}
+	sig, e := tag.GetSig(bt.Sig)
+	if e != nil {
+		return cipher.PubKey{}, boo.WrapType(e, boo.InvalidRead,
+			"invalid signature of transfer")
+	}
+	if e := cipher.VerifySignature(pk, sig, bt.hash()); e != nil {
+		return cipher.PubKey{}, boo.WrapType(e, boo.NotAuthorised,
+			"transfer is not signed by board")
+	}
+	return successor, nil
+}
+
+// SetDeleted marks the root as deleted with the given notice.
+func (rp *RootPage) SetDeleted(notice *BoardDeletion) {
+	rp.Del = true
+	rp.Sum = jsonMarshal(notice)
+}
+
+// GetDeletion obtains the deletion notice of the root, or nil if the root is not deleted.
+func (rp *RootPage) GetDeletion() *BoardDeletion {
+	if !rp.Del {
+		return nil
+	}
+	out := new(BoardDeletion)
+	jsonUnmarshal(rp.Sum, out)
+	return out
+}
+
 /*
 	<<< BOARD PAGE >>>
 */
@@ -413,6 +518,7 @@ type DiffPageJSON struct {
 type Changes struct {
 	NeedReset bool
 	Total     int
+	Last      string // Hash of last submission (empty if there are none).
 	New       []*Content
 }
 
@@ -467,6 +573,13 @@ func (dp *DiffPage) GetChanges(oldC *Changes) (*Changes, error) {
 
 	// Get counts.
 	newC.Total, _ = dp.Submissions.Len()
+	if newC.Total > 0 {
+		last, e := dp.GetOfIndex(newC.Total - 1)
+		if e != nil {
+			return nil, e
+		}
+		newC.Last = last.GetHeader().Hash
+	}
 
 	// Return if no old changes.
 	if oldC == nil {
@@ -479,6 +592,18 @@ func (dp *DiffPage) GetChanges(oldC *Changes) (*Changes, error) {
 		return newC, nil
 	}
 
+	// Submissions we have already seen should be unchanged.
+	if oldC.Total > 0 && oldC.Last != "" {
+		last, e := dp.GetOfIndex(oldC.Total - 1)
+		if e != nil {
+			return nil, e
+		}
+		if last.GetHeader().Hash != oldC.Last {
+			newC.NeedReset = true
+			return newC, nil
+		}
+	}
+
 	// Get content.
 	if oldC.Total < newC.Total {
 		newC.New = make([]*Content, newC.Total-oldC.Total)
@@ -807,7 +932,7 @@ func valueErr(e error, ref *skyobject.Ref) error {
 }
 
 func elemValueErr(e error, elem *skyobject.RefsElem) error {
-	return boo.WrapType(e, boo.InvalidRead,
+	return boo.WrapTypef(e, boo.InvalidRead,
 		"failed to obtain value from elem object of ref '%s'",
 		elem.String())
 }
@@ -45,6 +45,10 @@ type Body struct {
 	Tags     []string          `json:"tags,omitempty"`            // board, thread_vote, post_vote, user_vote
 	SubKeys  []MessengerSubKey `json:"submission_keys,omitempty"` // board
 	Creator  string            `json:"creator,omitempty"`         // thread, post, thread_vote, post_vote, user_vote
+	Lineage  []string          `json:"lineage,omitempty"`         // board (optional), boards that content is carried over from
+	ForkOf   string            `json:"fork_of,omitempty"`         // board (optional), board that this board is forked from
+	PoWBits  int               `json:"pow_bits,omitempty"`        // board (optional), proof-of-work difficulty required of submissions
+	Stamp    uint64            `json:"stamp,omitempty"`           // thread, post, thread_vote, post_vote, user_vote (optional), proof-of-work nonce
 }
 
 func NewBody(raw []byte) (*Body, error) {
@@ -110,6 +114,16 @@ func (c *Body) SetSubKeys(subKeys []*MessengerSubKeyTransport) {
 	}
 }
 
+// HasLineage determines whether content of board 'pkStr' is carried over to this board.
+func (c *Body) HasLineage(pkStr string) bool {
+	for _, v := range c.Lineage {
+		if v == pkStr {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Body) GetCreator() (cipher.PubKey, error) {
 	if pk, e := tag.GetPubKey(c.Creator); e != nil {
 		return pk, errGetFromBody(e, "creator")
@@ -229,10 +243,11 @@ func (c *Content) ToRep() *ContentRep {
 }
 
 type ContentRep struct {
-	PubKey string             `json:"public_key,omitempty"`
-	Header *ContentHeaderData `json:"header,omitempty"`
-	Body   interface{}        `json:"body,omitempty"`
-	Votes  interface{}        `json:"votes,omitempty"`
+	PubKey    string             `json:"public_key,omitempty"`
+	Header    *ContentHeaderData `json:"header,omitempty"`
+	Body      interface{}        `json:"body,omitempty"`
+	Votes     interface{}        `json:"votes,omitempty"`
+	Collapsed bool               `json:"collapsed,omitempty"` // Whether creator is filtered from perspective.
 }
 
 type ContentType string
@@ -16,6 +16,12 @@ import (
 	"time"
 )
 
+// DefaultWaitTimeout is the max duration to wait for a root sequence when none is specified.
+const DefaultWaitTimeout = time.Second * 30
+
+// DefaultSnapshotInterval is the min duration between snapshots saved after updates when none is specified.
+const DefaultSnapshotInterval = time.Minute
+
 var (
 	// ErrInstanceNotInitialized occurs when instance is not initialized.
 	ErrInstanceNotInitialized = boo.New(boo.NotAllowed, "instance not initialized")
@@ -29,12 +35,26 @@ type BoardInstance struct {
 
 	//adders []views.Adder // generates views.
 
-	mux sync.RWMutex // Only use (RLock/RUnlock) with reading root sequence.
+	mux sync.RWMutex // Only use (RLock/RUnlock) with reading root sequence or viewer.
 	n   *node.Node
 	p   *skyobject.Pack
 	h   *Headers
 	v   *Viewer
 
+	snapPath     string        // Path of snapshot file (snapshots disabled if empty).
+	snapInterval time.Duration // Min duration between snapshots saved after updates (default if 0).
+	snapSaved    time.Time     // Time of last saved snapshot.
+	bus          *EventBus     // Where changes are broadcast to (optional).
+
+	waitTimeout time.Duration // Max duration of 'WaitSeq' (default if 0).
+	seqChange   chan struct{} // Closed and replaced whenever the root sequence advances.
+
+	policy PublishPolicy // How changes are batched into published roots.
+	batch  publishBatch  // Pending changes and publishing metrics.
+
+	deleted *object.BoardDeletion // Deletion notice of board (nil if not deleted).
+	history viewerCache           // Recently used viewers of previous root sequences.
+
 	needPublish typ.Bool // Whether there are changes that need to be published.
 	needReset   typ.Bool // Whether a reset is needed.
 	isReceived  typ.Bool // Whether we have received this root.
@@ -45,16 +65,71 @@ type BoardInstance struct {
 func (bi *BoardInstance) Init(n *node.Node, pk cipher.PubKey) *BoardInstance {
 	bi.l = inform.NewLogger(true, os.Stdout, "INSTANCE:"+pk.Hex()[:5]+"...")
 	bi.n = n
+	bi.seqChange = make(chan struct{})
+
+	return bi
+}
+
+// SetWaitTimeout sets the max duration in which 'WaitSeq' waits for a root sequence.
+func (bi *BoardInstance) SetWaitTimeout(timeout time.Duration) *BoardInstance {
+	bi.mux.Lock()
+	defer bi.mux.Unlock()
+
+	bi.waitTimeout = timeout
+	return bi
+}
+
+// EnableSnapshot enables saving and restoring of headers and views to/from
+// the given file path. Should be called before the first update.
+func (bi *BoardInstance) EnableSnapshot(path string) *BoardInstance {
+	bi.mux.Lock()
+	defer bi.mux.Unlock()
+
+	bi.snapPath = path
+	return bi
+}
+
+// SetSnapshotInterval sets the min duration between snapshots that are saved after
+// publishing or receiving roots.
+func (bi *BoardInstance) SetSnapshotInterval(interval time.Duration) *BoardInstance {
+	bi.mux.Lock()
+	defer bi.mux.Unlock()
+
+	bi.snapInterval = interval
+	return bi
+}
+
+// SetEventBus sets the event bus in which compiled changes are broadcast to.
+// Should be called before the first update.
+func (bi *BoardInstance) SetEventBus(bus *EventBus) *BoardInstance {
+	bi.mux.Lock()
+	defer bi.mux.Unlock()
 
+	bi.bus = bus
 	return bi
 }
 
+// DiscardSnapshot disables snapshots and removes the snapshot file.
+func (bi *BoardInstance) DiscardSnapshot() {
+	bi.mux.Lock()
+	defer bi.mux.Unlock()
+
+	if bi.snapPath == "" {
+		return
+	}
+	if e := os.Remove(bi.snapPath); e != nil && !os.IsNotExist(e) {
+		bi.l.Println(" - failed to remove snapshot:", e)
+	}
+	bi.snapPath = ""
+}
+
 // Close closes the board instance.
 func (bi *BoardInstance) Close() {
 	bi.mux.Lock()
 	defer bi.mux.Unlock()
 
 	if bi.p != nil {
+		bi.saveSnapshot()
 		bi.p.Close()
 	}
 }
@@ -106,6 +181,13 @@ func (bi *BoardInstance) UpdateWithReceived(r *skyobject.Root, sk cipher.SecKey)
 
 	bi.l.Println(" - root unpack succeeded.")
 	bi.p = newPack
+	bi.notifySeq()
+	bi.checkDeleted()
+
+	if firstRun && bi.restoreSnapshot() {
+		bi.broadcastChanges(true, false)
+		return nil
+	}
 
 	newHeaders, e := NewHeaders(bi.h, bi.p)
 	if e != nil {
@@ -116,18 +198,23 @@ func (bi *BoardInstance) UpdateWithReceived(r *skyobject.Root, sk cipher.SecKey)
 	bi.l.Println(" - new headers successfully generated.")
 	bi.h = newHeaders
 
+	if newHeaders.GetChanges().NeedReset {
+		bi.l.Println(" - submissions have been reset, views will be recompiled.")
+	}
+
 	if firstRun {
 		if bi.v, e = NewViewer(bi.p); e != nil {
 			return e
 		}
+		bi.saveSnapshot()
 	} else {
 		if e := bi.v.Update(bi.p, bi.h); e != nil {
 			return e
 		}
+		bi.saveSnapshotIfDue()
 	}
 
-	// TODO: Broadcast changes.
-
+	bi.broadcastChanges(firstRun, false)
 	return nil
 }
 
@@ -173,6 +260,62 @@ func (bi *BoardInstance) fixRoot(firstRun bool, flags skyobject.Flag, goal uint6
 	return validPack, nil
 }
 
+// restoreSnapshot attempts to restore headers and views from snapshot.
+// Returns false if a full rebuild is required.
+// Should only be used when instance is locked.
+func (bi *BoardInstance) restoreSnapshot() bool {
+	if bi.snapPath == "" {
+		return false
+	}
+	snap, e := LoadSnapshot(bi.snapPath)
+	if e != nil {
+		if boo.Type(e) != boo.NotFound {
+			bi.l.Println(" - snapshot not used:", e)
+		}
+		return false
+	}
+	h, v, e := snap.Restore(bi.p)
+	if e != nil {
+		bi.l.Println(" - snapshot not used, rebuilding:", e)
+		return false
+	}
+	bi.h, bi.v = h, v
+	bi.l.Printf(" - restored from snapshot of seq(%d).", snap.RootSeq)
+	return true
+}
+
+// saveSnapshot saves headers and views to snapshot.
+// Snapshots are only saved when views represent the pack's current root.
+// Should only be used when instance is locked.
+func (bi *BoardInstance) saveSnapshot() {
+	if bi.snapPath == "" || bi.h == nil || bi.v == nil || bi.needPublish.Value() {
+		return
+	}
+	snap, e := NewSnapshot(bi.p, bi.h, bi.v)
+	if e != nil {
+		bi.l.Println(" - failed to generate snapshot:", e)
+		return
+	}
+	if e := snap.Save(bi.snapPath); e != nil {
+		bi.l.Println(" - failed to save snapshot:", e)
+		return
+	}
+	bi.snapSaved = time.Now()
+}
+
+// saveSnapshotIfDue saves a snapshot if the snapshot interval has passed since the
+// last saved snapshot, so that changes to recompile after a crash are bounded.
+// Should only be used when instance is locked.
+func (bi *BoardInstance) saveSnapshotIfDue() {
+	interval := bi.snapInterval
+	if interval <= 0 {
+		interval = DefaultSnapshotInterval
+	}
+	if time.Since(bi.snapSaved) >= interval {
+		bi.saveSnapshot()
+	}
+}
+
 // PublishChanges publishes changes to CXO.
 // Only use if instance is initialised, changes were made, and the node owns the board.
 // Should be triggered by compiler based on an interval.
@@ -181,11 +324,13 @@ func (bi *BoardInstance) PublishChanges() error {
 	if bi.needPublish.Value() == false {
 		return nil
 	}
-	defer bi.needPublish.Clear()
 
 	bi.mux.Lock()
 	defer bi.mux.Unlock()
 
+	// Cleared before unlocking so changes made after publishing are not dropped.
+	defer bi.needPublish.Clear()
+
 	if bi.p == nil || bi.p.Flags()&skyobject.ViewOnly > 0 {
 		return nil
 	}
@@ -195,9 +340,13 @@ func (bi *BoardInstance) PublishChanges() error {
 		return boo.WrapType(e, boo.Internal, "failed to save in cxo db")
 	}
 	bi.n.Publish(bi.p.Root())
+	bi.notifySeq()
+	bi.recordPublish()
+	bi.checkDeleted()
 
 	// Reset header and views if needed.
-	if bi.needReset.Value() {
+	reset := bi.needReset.Value()
+	if reset {
 
 		// Reset headers.
 		var e error
@@ -227,13 +376,48 @@ func (bi *BoardInstance) PublishChanges() error {
 		}
 	}
 
-	// TODO: Broadcast changes.
+	// Views now represent the published root.
+	bi.needPublish.Clear()
+	bi.saveSnapshotIfDue()
 
+	bi.broadcastChanges(false, reset)
 	return nil
 }
 
+// broadcastChanges publishes events for the changes of the current headers.
+// Should only be used when instance is locked.
+func (bi *BoardInstance) broadcastChanges(ready, reset bool) {
+	if bi.bus == nil {
+		return
+	}
+	var (
+		root    = bi.p.Root()
+		changes = bi.h.GetChanges()
+		events  = make([]*Event, 0, len(changes.New)+3)
+	)
+	if ready {
+		events = append(events, NewBoardEvent(EventBoardReady, root.Pub, root.Seq))
+	}
+	if reset || changes.NeedReset {
+		events = append(events, NewBoardEvent(EventBoardReset, root.Pub, root.Seq))
+	}
+	for _, c := range changes.New {
+		if bi.v.IsQuarantined(c.GetHeader().Hash) {
+			continue
+		}
+		if event := NewContentEvent(root.Pub, root.Seq, c, bi.v.GetThreadOfPost); event != nil {
+			events = append(events, event)
+		}
+	}
+	events = append(events, NewBoardEvent(EventBoardUpdated, root.Pub, root.Seq))
+	bi.bus.Publish(events...)
+}
+
 // Viewer obtains the viewer.
+// The viewer is replaced (under lock) when views are recompiled.
 func (bi *BoardInstance) Viewer() *Viewer {
+	bi.mux.RLock()
+	defer bi.mux.RUnlock()
 	return bi.v
 }
 
@@ -270,29 +454,108 @@ func (bi *BoardInstance) GetSummary(pk cipher.PubKey, sk cipher.SecKey) (*object
 	return out, nil
 }
 
+// notifySeq wakes routines waiting on the root sequence.
+// Should only be used when instance is locked.
+func (bi *BoardInstance) notifySeq() {
+	close(bi.seqChange)
+	bi.seqChange = make(chan struct{})
+}
+
 // WaitSeq waits until sequence reaches or surpassed the goal.
+// It returns when ctx is done, or after the instance's wait timeout.
 func (bi *BoardInstance) WaitSeq(ctx context.Context, goal uint64) error {
-	if bi.p == nil {
-		return ErrInstanceNotInitialized
-	} else if bi.GetSeq() >= goal {
-		return nil
+	bi.mux.RLock()
+	timeout := bi.waitTimeout
+	bi.mux.RUnlock()
+	if timeout <= 0 {
+		timeout = DefaultWaitTimeout
 	}
 
-	ctx, _ = context.WithTimeout(ctx, time.Second*30)
-
-	ticker := time.NewTicker(time.Second)
-	defer ticker.Stop()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
 	for {
+		bi.mux.RLock()
+		p, change := bi.p, bi.seqChange
+		var seq uint64
+		if p != nil {
+			seq = p.Root().Seq
+		}
+		bi.mux.RUnlock()
+
+		if p == nil {
+			return ErrInstanceNotInitialized
+		} else if seq >= goal {
+			return nil
+		}
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case <-ticker.C:
-			if bi.GetSeq() >= goal {
-				return nil
-			}
+		case <-change:
+		}
+	}
+}
+
+// CheckConsistency compares the compiled views against the content of the board's root.
+// If repair is set and views are found inconsistent, headers and views are recompiled.
+// Views only represent published roots, hence unpublished changes are not checked
+// and a board with unpublished changes cannot be repaired.
+func (bi *BoardInstance) CheckConsistency(repair bool) (*ConsistencyReport, error) {
+	bi.mux.Lock()
+	defer bi.mux.Unlock()
+
+	if bi.p == nil {
+		return nil, ErrInstanceNotInitialized
+	}
+	if bi.needPublish.Value() {
+		if repair {
+			return nil, boo.New(boo.NotAllowed,
+				"board has unpublished changes, repair after they are published")
 		}
+		return bi.checkPublished()
+	}
+	out, e := bi.v.check(bi.p, bi.h)
+	if e != nil || out.Consistent || !repair {
+		return out, e
+	}
+
+	bi.l.Println("views are inconsistent with root, recompiling.")
+	if bi.h, e = NewHeaders(nil, bi.p); e != nil {
+		return nil, boo.WrapType(e, boo.Internal, "failed to reset headers")
+	}
+	if e := bi.v.Reset(bi.p); e != nil {
+		return nil, boo.WrapType(e, boo.Internal, "failed to reset view")
 	}
+	bi.saveSnapshot()
+
+	if out, e = bi.v.check(bi.p, bi.h); e != nil {
+		return nil, e
+	}
+	out.Repaired = true
+	return out, nil
+}
+
+// checkPublished compares the compiled views against the last published root,
+// which the pack has unpublished changes of.
+// Should only be used when instance is locked.
+func (bi *BoardInstance) checkPublished() (*ConsistencyReport, error) {
+	var (
+		ct  = bi.n.Container()
+		pub = bi.p.Root() // Sequence is only incremented on save.
+	)
+	r, e := ct.Root(pub.Pub, pub.Seq)
+	if e != nil {
+		return nil, boo.WrapTypef(e, boo.NotFound, "published root of seq %d is not found", pub.Seq)
+	}
+	defer ct.UnholdRoot(r)
+
+	p, e := ct.Unpack(r, skyobject.ViewOnly, ct.CoreRegistry().Types(), cipher.SecKey{})
+	if e != nil {
+		return nil, boo.WrapTypef(e, boo.InvalidRead, "failed to unpack root of seq %d", pub.Seq)
+	}
+	defer p.Close()
+
+	return bi.v.check(p, bi.h)
 }
 
 // PackAction represents an action applied to a root pack.
@@ -307,12 +570,15 @@ func (bi *BoardInstance) EditPack(action PackAction) error {
 		return ErrInstanceNotInitialized
 	} else if bi.p.Flags()&skyobject.ViewOnly > 0 {
 		return ErrNotEditable
+	} else if bi.deleted != nil {
+		return ErrBoardDeleted(bi.p.Root().Pub, bi.deleted)
 	}
 
 	bi.needPublish.Set()
 	if e := action(bi.p, bi.h); e != nil {
 		return e
 	}
+	bi.queueChange()
 	return nil
 }
 
@@ -4,10 +4,15 @@ import (
 	"github.com/skycoin/bbs/src/misc/boo"
 	"github.com/skycoin/bbs/src/store/object"
 	"github.com/skycoin/cxo/skyobject"
+	"github.com/skycoin/skycoin/src/cipher"
 )
 
 func (bi *BoardInstance) Submit(transport *object.Transport) (uint64, error) {
 
+	if e := bi.CheckStamp(transport.Header.GetHash()); e != nil {
+		return 0, e
+	}
+
 	var goal uint64
 
 	switch transport.Body.Type {
@@ -36,6 +41,7 @@ func (bi *BoardInstance) Submit(transport *object.Transport) (uint64, error) {
 			"content has invalid type '%s'", transport.Body.Type)
 	}
 
+	bi.publishIfDue()
 	return goal, nil
 }
 
@@ -238,6 +244,48 @@ func (bi *BoardInstance) GetSubmissionKeys() []*object.MessengerSubKeyTransport
 	return subKeys
 }
 
+// SetPoWBits sets the proof-of-work difficulty advertised by the board.
+// Submissions are required to have content hashes of at least 'n' leading zero bits.
+func (bi *BoardInstance) SetPoWBits(n int) (uint64, error) {
+	if e := object.CheckPoWBits(n); e != nil {
+		return 0, e
+	}
+	bi.l.Printf("setting proof-of-work difficulty as: %d bits", n)
+	return bi.EditBoard(func(board *object.Content) (bool, error) {
+		body := board.GetBody()
+		if body.PoWBits == n {
+			return false, nil
+		}
+		body.PoWBits = n
+		board.SetBody(body)
+		return true, nil
+	})
+}
+
+// GetPoWBits obtains the proof-of-work difficulty advertised by the board.
+func (bi *BoardInstance) GetPoWBits() int {
+	var n int
+	if e := bi.ViewBoard(func(board *object.Content) (bool, error) {
+		n = board.GetBody().PoWBits
+		return false, nil
+	}); e != nil {
+		bi.l.Println("error obtaining proof-of-work difficulty:", e)
+		return 0
+	}
+	return n
+}
+
+// CheckStamp checks that the hash of a submission's raw body has a proof-of-work
+// stamp that satisfies the difficulty advertised by the board.
+// Submissions received from remote nodes are checked before their signatures are
+// verified, as this is far cheaper.
+func (bi *BoardInstance) CheckStamp(hash cipher.SHA256) error {
+	if n := bi.GetPoWBits(); n > 0 {
+		return object.CheckStamp(hash, n)
+	}
+	return nil
+}
+
 // BoardAction is a function in which board modification/viewing takes place.
 // Returns a boolean that represents whether changes have been made and
 // an error on failure.
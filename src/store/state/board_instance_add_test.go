@@ -1,6 +1,7 @@
 package state
 
 import (
+	"context"
 	"github.com/skycoin/bbs/src/store/object"
 	"github.com/skycoin/skycoin/src/cipher"
 	"math/rand"
@@ -190,3 +191,71 @@ func TestBoardInstance_NewThread(t *testing.T) {
 		wg.Wait()
 	})
 }
+
+func TestBoardInstance_Submit_Stamp(t *testing.T) {
+	const (
+		boardSeed = "a"
+		userSeed  = "b"
+		powBits   = 8
+	)
+
+	bi, quit := initInstance(t, boardSeed)
+	defer quit()
+
+	if _, e := bi.SetPoWBits(object.MaxPoWBits + 1); e == nil {
+		t.Error("expected out of range difficulty to be refused")
+	}
+	if _, e := bi.SetPoWBits(powBits); e != nil {
+		t.Fatal("failed to set proof-of-work difficulty:", e)
+	}
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+	if got := bi.GetPoWBits(); got != powBits {
+		t.Fatalf("expected advertised difficulty of %d bits, got %d", powBits, got)
+	}
+
+	cpk, csk := cipher.GenerateDeterministicKeyPair([]byte(userSeed))
+	body := &object.Body{
+		Type:    object.V5ThreadType,
+		TS:      time.Now().UnixNano(),
+		OfBoard: obtainBoardPubKey(t, bi).Hex(),
+		Name:    "Stamped Thread",
+		Body:    "A test thread with a proof-of-work stamp.",
+		Creator: cpk.Hex(),
+	}
+
+	// Find a body that does not satisfy the difficulty by chance.
+	for {
+		hash, _ := body.ToRaw()
+		if object.StampBits(hash) < powBits {
+			break
+		}
+		body.TS++
+	}
+	hash, raw := body.ToRaw()
+	if e := bi.CheckStamp(hash); e == nil {
+		t.Error("expected hash without stamp to be rejected")
+	}
+	transport, e := object.NewTransport(raw, cipher.SignHash(hash, csk))
+	if e != nil {
+		t.Fatal("failed to generate transport:", e)
+	}
+	if _, e := bi.Submit(transport); e == nil {
+		t.Error("expected submission without stamp to be rejected")
+	}
+
+	if hash, raw, e = body.SetStamp(context.Background(), powBits); e != nil {
+		t.Fatal("failed to set stamp:", e)
+	}
+	if e := bi.CheckStamp(hash); e != nil {
+		t.Fatal("expected stamped hash to satisfy difficulty:", e)
+	}
+	transport, e = object.NewTransport(raw, cipher.SignHash(hash, csk))
+	if e != nil {
+		t.Fatal("failed to generate transport:", e)
+	}
+	if _, e := bi.Submit(transport); e != nil {
+		t.Error("expected stamped submission to be accepted:", e)
+	}
+}
@@ -0,0 +1,69 @@
+package state
+
+import (
+	"github.com/skycoin/bbs/src/misc/boo"
+	"github.com/skycoin/bbs/src/store/object"
+	"github.com/skycoin/cxo/skyobject"
+	"github.com/skycoin/skycoin/src/cipher"
+)
+
+// ErrBoardDeleted generates the error returned when accessing a deleted board.
+func ErrBoardDeleted(pk cipher.PubKey, notice *object.BoardDeletion) error {
+	msg := "board '" + pk.Hex()[:5] + "...' has been deleted by it's master"
+	if notice.Reason != "" {
+		msg += ", reason: " + notice.Reason
+	}
+	if notice.Successor != "" {
+		msg += ", successor: " + notice.Successor
+	}
+	return boo.New(boo.NotFound, msg)
+}
+
+// GetDeletion obtains the deletion notice of the board, or nil if the board is not deleted.
+func (bi *BoardInstance) GetDeletion() *object.BoardDeletion {
+	bi.mux.RLock()
+	defer bi.mux.RUnlock()
+	return bi.deleted
+}
+
+// PublishDeletion publishes a final root of the board that is marked as deleted.
+// No further changes can be made to the board afterwards.
+func (bi *BoardInstance) PublishDeletion(notice *object.BoardDeletion) error {
+	e := bi.EditPack(func(p *skyobject.Pack, h *Headers) error {
+		rp, e := object.GetRootPage(p)
+		if e != nil {
+			return e
+		}
+		rp.SetDeleted(notice)
+		return rp.Save(p)
+	})
+	if e != nil {
+		return e
+	}
+	return bi.PublishChanges()
+}
+
+// checkDeleted determines whether the current root is marked as deleted.
+// An event is broadcast when the board is first found deleted.
+// Should only be used when instance is locked.
+func (bi *BoardInstance) checkDeleted() {
+	if bi.deleted != nil {
+		return
+	}
+	rp, e := object.GetRootPage(bi.p)
+	if e != nil {
+		bi.l.Println(" - failed to obtain root page:", e)
+		return
+	}
+	if bi.deleted = rp.GetDeletion(); bi.deleted == nil {
+		return
+	}
+	root := bi.p.Root()
+	bi.l.Printf(" - board deleted at seq %d (reason: '%s', successor: '%s')",
+		root.Seq, bi.deleted.Reason, bi.deleted.Successor)
+	if bi.bus != nil {
+		event := NewBoardEvent(EventBoardDeleted, root.Pub, root.Seq)
+		event.Deletion = bi.deleted
+		bi.bus.Publish(event)
+	}
+}
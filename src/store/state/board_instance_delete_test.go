@@ -0,0 +1,119 @@
+package state
+
+import (
+	"github.com/skycoin/bbs/src/misc/typ"
+	"github.com/skycoin/bbs/src/store/object"
+	"github.com/skycoin/cxo/skyobject"
+	"github.com/skycoin/skycoin/src/cipher"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestBoardInstance_PublishDeletion(t *testing.T) {
+	const (
+		boardSeed = "a"
+		userSeed  = "b"
+	)
+
+	bi, quit := initInstance(t, boardSeed)
+	defer quit()
+
+	addThread(t, bi, 0, []byte(userSeed))
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+	if bi.GetDeletion() != nil {
+		t.Fatal("expected board to not be deleted")
+	}
+
+	notice := &object.BoardDeletion{
+		Reason:    "moved",
+		Successor: obtainBoardPubKey(t, bi).Hex(),
+		TS:        time.Now().UnixNano(),
+	}
+	if e := bi.PublishDeletion(notice); e != nil {
+		t.Fatal("failed to publish deletion:", e)
+	}
+
+	got := bi.GetDeletion()
+	if got == nil {
+		t.Fatal("expected board to be deleted")
+	}
+	if *got != *notice {
+		t.Errorf("expected deletion notice %+v, got %+v", notice, got)
+	}
+
+	e := bi.EditPack(func(p *skyobject.Pack, h *Headers) error { return nil })
+	if e == nil {
+		t.Error("expected changes to deleted board to be refused")
+	}
+}
+
+func TestBoardInstance_Import_CarryOver(t *testing.T) {
+	const (
+		boardSeed     = "a"
+		successorSeed = "b"
+		userSeed      = "c"
+	)
+
+	n := prepareNode(t)
+	defer n.Close()
+
+	pk, sk, r := prepareBoard(t, n, boardSeed)
+	bi := prepareInstance(t, n, pk)
+	defer bi.Close()
+	if e := bi.UpdateWithReceived(r, sk); e != nil {
+		t.Fatal("failed to update board instance:", e)
+	}
+	npk, nsk, nr := prepareBoard(t, n, successorSeed)
+	ni := prepareInstance(t, n, npk)
+	defer ni.Close()
+	if e := ni.UpdateWithReceived(nr, nsk); e != nil {
+		t.Fatal("failed to update board instance:", e)
+	}
+
+	addThread(t, bi, 0, []byte(userSeed))
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+
+	pages, e := bi.Export(pk, sk)
+	if e != nil {
+		t.Fatal("failed to export board:", e)
+	}
+	if e := pages.CarryOver(npk, nsk); e != nil {
+		t.Fatal("failed to carry over pages:", e)
+	}
+	if _, e := ni.Import(pages); e != nil {
+		t.Fatal("failed to import pages:", e)
+	}
+	if e := ni.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+
+	out, e := ni.Viewer().GetBoardPage(&BoardPageIn{
+		PaginatedInput: typ.PaginatedInput{PageSize: math.MaxUint64},
+	})
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(out.Threads) != 1 {
+		t.Errorf("expected carried over thread to be accepted, got %d threads", len(out.Threads))
+	}
+
+	t.Run("transfer record", func(t *testing.T) {
+		transfer := object.NewBoardTransfer(pk, sk, npk, time.Now().UnixNano())
+		if got, e := transfer.Verify(pk); e != nil || got != npk {
+			t.Errorf("expected transfer to verify to successor, got %v (%v)", got, e)
+		}
+		if _, e := transfer.Verify(npk); e == nil {
+			t.Error("expected transfer of another board to fail verification")
+		}
+		forged := *transfer
+		forged.Successor = cipher.PubKey{}.Hex()
+		if _, e := forged.Verify(pk); e == nil {
+			t.Error("expected forged transfer to fail verification")
+		}
+	})
+}
@@ -0,0 +1,196 @@
+package state
+
+import (
+	"bytes"
+	"github.com/skycoin/bbs/src/store/object"
+	"github.com/skycoin/cxo/skyobject"
+	"sort"
+)
+
+// ContentChange represents content that is replaced between two root sequences.
+type ContentChange struct {
+	From *object.ContentRep `json:"from"`
+	To   *object.ContentRep `json:"to"`
+}
+
+// ContentDiff represents content that is added, removed or changed between two root sequences.
+type ContentDiff struct {
+	Added   []*object.ContentRep `json:"added"`
+	Removed []*object.ContentRep `json:"removed"`
+	Changed []*ContentChange     `json:"changed"`
+}
+
+// BoardDiffOut represents the changes of a board between two root sequences.
+type BoardDiffOut struct {
+	BoardPubKey string         `json:"board_public_key"`
+	FromSeq     uint64         `json:"from_seq"`
+	ToSeq       uint64         `json:"to_seq"`
+	Board       *ContentChange `json:"board,omitempty"` // Nil if board metadata is unchanged.
+	Threads     *ContentDiff   `json:"threads"`
+	Posts       *ContentDiff   `json:"posts"`
+	Votes       *ContentDiff   `json:"votes"` // Only the latest vote of each creator on each target is compared.
+
+	// Hashes of submissions recorded in 'DiffPage' between the two sequences.
+	// If 'DiffReset' is set, 'DiffPage' of 'FromSeq' is not a prefix of that of
+	// 'ToSeq', and all submissions of 'ToSeq' not in 'FromSeq' are listed.
+	Submissions []string `json:"submissions"`
+	DiffReset   bool     `json:"diff_reset"`
+}
+
+// boardState represents the content of a board as of a root sequence.
+type boardState struct {
+	board       *object.Content
+	threads     map[string]*object.Content
+	posts       map[string]*object.Content
+	votes       map[string]*object.Content // Key is "<creator>,<target>".
+	submissions []string
+}
+
+func getBoardState(p *skyobject.Pack) (*boardState, error) {
+	pages, e := object.GetPages(p, &object.GetPagesIn{
+		RootPage:  false,
+		BoardPage: true,
+		DiffPage:  true,
+		UsersPage: true,
+	})
+	if e != nil {
+		return nil, e
+	}
+	out := &boardState{
+		threads: make(map[string]*object.Content),
+		posts:   make(map[string]*object.Content),
+		votes:   make(map[string]*object.Content),
+	}
+	if out.board, e = pages.BoardPage.GetBoard(); e != nil {
+		return nil, e
+	}
+	e = pages.BoardPage.RangeThreadPages(func(_ int, tp *object.ThreadPage) error {
+		thread, e := tp.GetThread()
+		if e != nil {
+			return e
+		}
+		out.threads[thread.GetHeader().Hash] = thread
+		return tp.RangePosts(func(_ int, post *object.Content) error {
+			out.posts[post.GetHeader().Hash] = post
+			return nil
+		})
+	})
+	if e != nil {
+		return nil, e
+	}
+	e = pages.UsersPage.RangeUserProfiles(func(_ int, uap *object.UserProfile) error {
+		return uap.RangeSubmissions(func(_ int, c *object.Content) error {
+			body := c.GetBody()
+			var target string
+			switch body.Type {
+			case object.V5ThreadVoteType:
+				target = body.OfThread
+			case object.V5PostVoteType:
+				target = body.OfPost
+			case object.V5UserVoteType:
+				target = body.OfUser
+			default:
+				return nil
+			}
+			// Submissions are in order of creation, so later votes replace earlier ones.
+			out.votes[body.Creator+","+target] = c
+			return nil
+		})
+	})
+	if e != nil {
+		return nil, e
+	}
+	count, e := pages.DiffPage.Submissions.Len()
+	if e != nil {
+		return nil, e
+	}
+	for i := 0; i < count; i++ {
+		c, e := pages.DiffPage.GetOfIndex(i)
+		if e != nil {
+			return nil, e
+		}
+		out.submissions = append(out.submissions, c.GetHeader().Hash)
+	}
+	return out, nil
+}
+
+func diffContent(from, to map[string]*object.Content) *ContentDiff {
+	out := &ContentDiff{
+		Added:   make([]*object.ContentRep, 0),
+		Removed: make([]*object.ContentRep, 0),
+		Changed: make([]*ContentChange, 0),
+	}
+	for _, k := range sortedKeys(to) {
+		if old, ok := from[k]; !ok {
+			out.Added = append(out.Added, to[k].ToRep())
+		} else if !contentEqual(old, to[k]) {
+			out.Changed = append(out.Changed, &ContentChange{From: old.ToRep(), To: to[k].ToRep()})
+		}
+	}
+	for _, k := range sortedKeys(from) {
+		if _, ok := to[k]; !ok {
+			out.Removed = append(out.Removed, from[k].ToRep())
+		}
+	}
+	return out
+}
+
+func contentEqual(a, b *object.Content) bool {
+	return bytes.Equal(a.Header, b.Header) && bytes.Equal(a.Body, b.Body)
+}
+
+func sortedKeys(m map[string]*object.Content) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Diff obtains the changes of the board from root sequence 'from' to root sequence 'to'.
+func (bi *BoardInstance) Diff(from, to uint64) (*BoardDiffOut, error) {
+	var (
+		out    = &BoardDiffOut{FromSeq: from, ToSeq: to}
+		states [2]*boardState
+	)
+	for i, seq := range [2]uint64{from, to} {
+		e := bi.viewPackAt(seq, func(p *skyobject.Pack) (e error) {
+			out.BoardPubKey = p.Root().Pub.Hex()
+			states[i], e = getBoardState(p)
+			return e
+		})
+		if e != nil {
+			return nil, e
+		}
+	}
+	a, b := states[0], states[1]
+
+	if !contentEqual(a.board, b.board) {
+		out.Board = &ContentChange{From: a.board.ToRep(), To: b.board.ToRep()}
+	}
+	out.Threads = diffContent(a.threads, b.threads)
+	out.Posts = diffContent(a.posts, b.posts)
+	out.Votes = diffContent(a.votes, b.votes)
+
+	out.Submissions = make([]string, 0)
+	isPrefix := len(a.submissions) <= len(b.submissions)
+	for i := 0; isPrefix && i < len(a.submissions); i++ {
+		isPrefix = a.submissions[i] == b.submissions[i]
+	}
+	if isPrefix {
+		out.Submissions = append(out.Submissions, b.submissions[len(a.submissions):]...)
+	} else {
+		out.DiffReset = true
+		seen := make(map[string]struct{}, len(a.submissions))
+		for _, hash := range a.submissions {
+			seen[hash] = struct{}{}
+		}
+		for _, hash := range b.submissions {
+			if _, ok := seen[hash]; !ok {
+				out.Submissions = append(out.Submissions, hash)
+			}
+		}
+	}
+	return out, nil
+}
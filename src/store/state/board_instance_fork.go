@@ -0,0 +1,67 @@
+package state
+
+import (
+	"github.com/skycoin/bbs/src/misc/boo"
+	"github.com/skycoin/bbs/src/store/object"
+	"github.com/skycoin/cxo/skyobject"
+)
+
+// ReplayOut represents the result of replaying the submissions of a board.
+type ReplayOut struct {
+	Goal     uint64   `json:"goal"`     // Root sequence that includes replayed submissions.
+	Replayed int      `json:"replayed"` // Number of replayed submissions.
+	Skipped  []string `json:"skipped"`  // Hashes of submissions that could not be replayed.
+}
+
+// Replay submits the submissions of board 'origin' to this board in their original order.
+// Content is submitted as signed by it's creators, hence original signatures and authors
+// are preserved. Submissions that fail verification (or are quarantined) are skipped.
+// This board should have 'origin' in it's lineage, so that replayed content is accepted.
+func (bi *BoardInstance) Replay(origin *BoardInstance) (*ReplayOut, error) {
+	var subs []*object.Content
+	e := origin.ViewPack(func(p *skyobject.Pack, h *Headers) error {
+		dp, e := object.GetDiffPage(p)
+		if e != nil {
+			return e
+		}
+		dpJSON, e := dp.ToJSON()
+		if e != nil {
+			return e
+		}
+		subs = dpJSON.Submissions
+		return nil
+	})
+	if e != nil {
+		return nil, e
+	}
+
+	out := &ReplayOut{Skipped: make([]string, 0)}
+	for _, c := range subs {
+		header := c.GetHeader()
+		if origin.Viewer().IsQuarantined(header.Hash) {
+			out.Skipped = append(out.Skipped, header.Hash)
+			continue
+		}
+		transport, e := object.NewTransport(c.Body, header.GetSig())
+		if e != nil {
+			bi.l.Printf(" - skipped replay of '%s': %v", header.Hash, e)
+			out.Skipped = append(out.Skipped, header.Hash)
+			continue
+		}
+		goal, e := bi.Submit(transport)
+		if boo.Type(e) == boo.NotFound {
+			// Content may refer to content that is yet to be compiled.
+			if e = bi.PublishChanges(); e == nil {
+				goal, e = bi.Submit(transport)
+			}
+		}
+		if e != nil {
+			bi.l.Printf(" - skipped replay of '%s': %v", header.Hash, e)
+			out.Skipped = append(out.Skipped, header.Hash)
+			continue
+		}
+		out.Goal = goal
+		out.Replayed++
+	}
+	return out, nil
+}
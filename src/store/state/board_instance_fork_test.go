@@ -0,0 +1,116 @@
+package state
+
+import (
+	"github.com/skycoin/bbs/src/misc/typ"
+	"github.com/skycoin/bbs/src/store/cxo/setup"
+	"github.com/skycoin/bbs/src/store/object"
+	"github.com/skycoin/cxo/skyobject"
+	"github.com/skycoin/skycoin/src/cipher"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestBoardInstance_Replay(t *testing.T) {
+	const (
+		boardSeed = "a"
+		forkSeed  = "b"
+		userSeed  = "c"
+	)
+
+	n := prepareNode(t)
+	defer n.Close()
+
+	pk, sk, r := prepareBoard(t, n, boardSeed)
+	origin := prepareInstance(t, n, pk)
+	defer origin.Close()
+	if e := origin.UpdateWithReceived(r, sk); e != nil {
+		t.Fatal("failed to update board instance:", e)
+	}
+
+	tHash, _ := addThread(t, origin, 0, []byte(userSeed))
+	if e := origin.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+	addPost(t, origin, tHash, 0, []byte(userSeed))
+	addThreadVote(t, origin, tHash, +1, []byte(userSeed))
+	if e := origin.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+
+	// Fork board records it's origin.
+	fpk, fsk := cipher.GenerateDeterministicKeyPair([]byte(forkSeed))
+	content := new(object.Content)
+	content.SetHeader(&object.ContentHeaderData{})
+	content.SetBody(&object.Body{
+		Type:    object.V5BoardType,
+		TS:      time.Now().UnixNano(),
+		Name:    "Fork",
+		Lineage: []string{pk.Hex()},
+		ForkOf:  pk.Hex(),
+	})
+	if e := n.AddFeed(fpk); e != nil {
+		t.Fatal("failed to add feed:", e)
+	}
+	fr, e := setup.NewBoard(n, content, fpk, fsk)
+	if e != nil {
+		t.Fatal("failed to create fork board:", e)
+	}
+	fork := prepareInstance(t, n, fpk)
+	defer fork.Close()
+	if e := fork.UpdateWithReceived(fr, fsk); e != nil {
+		t.Fatal("failed to update board instance:", e)
+	}
+
+	out, e := fork.Replay(origin)
+	if e != nil {
+		t.Fatal("failed to replay:", e)
+	}
+	if out.Replayed != 3 || len(out.Skipped) != 0 {
+		t.Errorf("expected 3 replayed submissions, got %d (skipped %v)", out.Replayed, out.Skipped)
+	}
+	if e := fork.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+
+	page, e := fork.Viewer().GetThreadPage(&ThreadPageIn{
+		ThreadHash:     tHash.Hex(),
+		PaginatedInput: typ.PaginatedInput{PageSize: math.MaxUint64},
+	})
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(page.Posts) != 1 {
+		t.Errorf("expected 1 replayed post, got %d", len(page.Posts))
+	}
+
+	// Original signatures are preserved.
+	var orig, forked *object.Content
+	origin.ViewPack(func(p *skyobject.Pack, h *Headers) error {
+		dp, _ := object.GetDiffPage(p)
+		orig, _ = dp.GetOfIndex(0)
+		return nil
+	})
+	fork.ViewPack(func(p *skyobject.Pack, h *Headers) error {
+		dp, _ := object.GetDiffPage(p)
+		forked, _ = dp.GetOfIndex(0)
+		return nil
+	})
+	if orig == nil || forked == nil {
+		t.Fatal("failed to obtain first submissions")
+	}
+	if orig.GetHeader().Sig != forked.GetHeader().Sig {
+		t.Error("expected signature of replayed submission to be preserved")
+	}
+
+	t.Run("replay again", func(t *testing.T) {
+		out, e := fork.Replay(origin)
+		if e != nil {
+			t.Fatal(e)
+		}
+		// Thread and post already exist, while votes replace existing votes.
+		if len(out.Skipped) != 2 {
+			t.Errorf("expected existing thread and post to be skipped, got %+v", out)
+		}
+	})
+}
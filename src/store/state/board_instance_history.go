@@ -0,0 +1,164 @@
+package state
+
+import (
+	"github.com/skycoin/bbs/src/misc/boo"
+	"github.com/skycoin/bbs/src/store/object"
+	"github.com/skycoin/cxo/data"
+	"github.com/skycoin/cxo/skyobject"
+	"github.com/skycoin/skycoin/src/cipher"
+	"sync"
+)
+
+// RootInfo represents a root sequence of a board that is available locally.
+type RootInfo struct {
+	Seq  uint64 `json:"seq"`
+	Hash string `json:"hash"`
+	TS   int64  `json:"ts"` // Time in which root is created (unix nano).
+}
+
+// pk obtains the public key of the board.
+func (bi *BoardInstance) pk() (cipher.PubKey, error) {
+	bi.mux.RLock()
+	defer bi.mux.RUnlock()
+	if bi.p == nil {
+		return cipher.PubKey{}, ErrInstanceNotInitialized
+	}
+	return bi.p.Root().Pub, nil
+}
+
+// GetRoots obtains the root sequences of the board that are kept locally, in ascending order.
+func (bi *BoardInstance) GetRoots() ([]*RootInfo, error) {
+	pk, e := bi.pk()
+	if e != nil {
+		return nil, e
+	}
+	ct := bi.n.Container()
+
+	var seqs []uint64
+	e = ct.DB().IdxDB().Tx(func(feeds data.Feeds) error {
+		rs, e := feeds.Roots(pk)
+		if e != nil {
+			return e
+		}
+		return rs.Ascend(func(ir *data.Root) error {
+			seqs = append(seqs, ir.Seq)
+			return nil
+		})
+	})
+	if e != nil {
+		return nil, boo.WrapType(e, boo.InvalidRead, "failed to obtain roots of board")
+	}
+
+	out := make([]*RootInfo, 0, len(seqs))
+	for _, seq := range seqs {
+		r, e := ct.Root(pk, seq)
+		if e != nil {
+			// Root may have been removed since.
+			continue
+		}
+		out = append(out, &RootInfo{Seq: r.Seq, Hash: r.Hash.Hex(), TS: r.Time})
+		ct.UnholdRoot(r)
+	}
+	return out, nil
+}
+
+// GetViewerAt obtains a read-only viewer of the board as of the given root sequence.
+// Viewers are compiled on demand, and the most recently used are cached.
+func (bi *BoardInstance) GetViewerAt(seq uint64) (*Viewer, error) {
+	if v, ok := bi.history.get(seq); ok {
+		return v, nil
+	}
+	var v *Viewer
+	e := bi.viewPackAt(seq, func(p *skyobject.Pack) (e error) {
+		v, e = NewViewer(p)
+		return e
+	})
+	if e != nil {
+		return nil, e
+	}
+	bi.history.add(seq, v)
+	return v, nil
+}
+
+// viewPackAt unpacks the root of the given sequence as read-only, and applies action on it.
+// The root is held (and hence not removed) until action returns.
+func (bi *BoardInstance) viewPackAt(seq uint64, action func(p *skyobject.Pack) error) error {
+	pk, e := bi.pk()
+	if e != nil {
+		return e
+	}
+	ct := bi.n.Container()
+
+	r, e := ct.Root(pk, seq)
+	if e != nil {
+		return boo.WrapTypef(e, boo.NotFound, "root of seq %d is not found", seq)
+	}
+	defer ct.UnholdRoot(r)
+
+	if len(r.Refs) != object.RootChildrenCount {
+		return boo.Newf(boo.InvalidRead, "root of seq %d does not represent a board", seq)
+	}
+	p, e := ct.Unpack(r, skyobject.ViewOnly, ct.CoreRegistry().Types(), cipher.SecKey{})
+	if e != nil {
+		return boo.WrapTypef(e, boo.InvalidRead, "failed to unpack root of seq %d", seq)
+	}
+	defer p.Close()
+
+	return action(p)
+}
+
+/*
+	<<< VIEWER CACHE >>>
+*/
+
+// HistoryCacheSize is the max number of historical viewers cached per board.
+const HistoryCacheSize = 8
+
+// viewerCache keeps the most recently used historical viewers of a board.
+// As roots of a given sequence do not change, cached viewers never go stale.
+type viewerCache struct {
+	mux   sync.Mutex
+	seqs  []uint64 // In order of use (most recent last).
+	views map[uint64]*Viewer
+}
+
+func (c *viewerCache) get(seq uint64) (*Viewer, bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	v, ok := c.views[seq]
+	if ok {
+		c.touch(seq)
+	}
+	return v, ok
+}
+
+func (c *viewerCache) add(seq uint64, v *Viewer) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.views == nil {
+		c.views = make(map[uint64]*Viewer)
+	}
+	if _, ok := c.views[seq]; ok {
+		c.touch(seq)
+		return
+	}
+	c.views[seq] = v
+	c.seqs = append(c.seqs, seq)
+	if len(c.seqs) > HistoryCacheSize {
+		delete(c.views, c.seqs[0])
+		c.seqs = c.seqs[1:]
+	}
+}
+
+// touch marks the sequence as most recently used.
+// Should only be used when cache is locked.
+func (c *viewerCache) touch(seq uint64) {
+	for i, s := range c.seqs {
+		if s == seq {
+			c.seqs = append(append(c.seqs[:i:i], c.seqs[i+1:]...), seq)
+			return
+		}
+	}
+}
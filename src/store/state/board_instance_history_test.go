@@ -0,0 +1,166 @@
+package state
+
+import (
+	"github.com/skycoin/bbs/src/misc/typ"
+	"math"
+	"testing"
+)
+
+func TestBoardInstance_GetViewerAt(t *testing.T) {
+	const (
+		boardSeed = "a"
+		userSeed  = "b"
+	)
+
+	bi, quit := initInstance(t, boardSeed)
+	defer quit()
+
+	addThread(t, bi, 0, []byte(userSeed))
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+	oldSeq := bi.GetSeq()
+
+	addThread(t, bi, 1, []byte(userSeed))
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+	newSeq := bi.GetSeq()
+
+	roots, e := bi.GetRoots()
+	if e != nil {
+		t.Fatal(e)
+	}
+	seqs := make(map[uint64]bool)
+	for i, r := range roots {
+		seqs[r.Seq] = true
+		if i > 0 && (r.Seq <= roots[i-1].Seq || r.TS < roots[i-1].TS) {
+			t.Errorf("roots are not in ascending order at index %d", i)
+		}
+	}
+	if !seqs[oldSeq] || !seqs[newSeq] {
+		t.Fatalf("expected roots to include seqs %d and %d", oldSeq, newSeq)
+	}
+
+	threadCount := func(seq uint64) int {
+		v, e := bi.GetViewerAt(seq)
+		if e != nil {
+			t.Fatal(e)
+		}
+		out, e := v.GetBoardPage(&BoardPageIn{
+			PaginatedInput: typ.PaginatedInput{PageSize: math.MaxUint64},
+		})
+		if e != nil {
+			t.Fatal(e)
+		}
+		return len(out.Threads)
+	}
+	if n := threadCount(oldSeq); n != 1 {
+		t.Errorf("expected 1 thread at seq %d, got %d", oldSeq, n)
+	}
+	if n := threadCount(newSeq); n != 2 {
+		t.Errorf("expected 2 threads at seq %d, got %d", newSeq, n)
+	}
+
+	if _, e := bi.GetViewerAt(newSeq + 100); e == nil {
+		t.Error("expected error for unknown seq")
+	}
+
+	t.Run("released", func(t *testing.T) {
+		if bi.n.Container().IsHolded(obtainBoardPubKey(t, bi), oldSeq) {
+			t.Errorf("expected root of seq %d to be released", oldSeq)
+		}
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		v1, e := bi.GetViewerAt(oldSeq)
+		if e != nil {
+			t.Fatal(e)
+		}
+		v2, e := bi.GetViewerAt(oldSeq)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if v1 != v2 {
+			t.Error("expected viewer to be obtained from cache")
+		}
+	})
+}
+
+func TestViewerCache(t *testing.T) {
+	var c viewerCache
+	for seq := uint64(0); seq < HistoryCacheSize; seq++ {
+		c.add(seq, new(Viewer))
+	}
+	// Using seq 0 makes seq 1 the least recently used.
+	if _, ok := c.get(0); !ok {
+		t.Fatal("expected seq 0 to be cached")
+	}
+	c.add(HistoryCacheSize, new(Viewer))
+
+	if _, ok := c.get(1); ok {
+		t.Error("expected least recently used seq 1 to be evicted")
+	}
+	for _, seq := range []uint64{0, 2, HistoryCacheSize} {
+		if _, ok := c.get(seq); !ok {
+			t.Errorf("expected seq %d to be cached", seq)
+		}
+	}
+	if len(c.views) != HistoryCacheSize || len(c.seqs) != HistoryCacheSize {
+		t.Errorf("expected %d cached viewers, got %d", HistoryCacheSize, len(c.views))
+	}
+}
+
+func TestBoardInstance_Diff(t *testing.T) {
+	const (
+		boardSeed = "a"
+		userSeed  = "b"
+	)
+
+	bi, quit := initInstance(t, boardSeed)
+	defer quit()
+
+	tHash, _ := addThread(t, bi, 0, []byte(userSeed))
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+	fromSeq := bi.GetSeq()
+
+	addPost(t, bi, tHash, 0, []byte(userSeed))
+	addThreadVote(t, bi, tHash, +1, []byte(userSeed))
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+	toSeq := bi.GetSeq()
+
+	out, e := bi.Diff(fromSeq, toSeq)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if out.Board != nil {
+		t.Error("expected board metadata to be unchanged")
+	}
+	if len(out.Threads.Added) != 0 || len(out.Threads.Removed) != 0 {
+		t.Errorf("expected threads to be unchanged, got %+v", out.Threads)
+	}
+	if len(out.Posts.Added) != 1 || len(out.Votes.Added) != 1 {
+		t.Errorf("expected 1 added post and vote, got %d and %d",
+			len(out.Posts.Added), len(out.Votes.Added))
+	}
+	if len(out.Submissions) != 2 || out.DiffReset {
+		t.Errorf("expected 2 new submissions, got %v (reset: %v)", out.Submissions, out.DiffReset)
+	}
+
+	t.Run("reverse", func(t *testing.T) {
+		out, e := bi.Diff(toSeq, fromSeq)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if len(out.Posts.Removed) != 1 || len(out.Votes.Removed) != 1 || len(out.Posts.Added) != 0 {
+			t.Errorf("expected 1 removed post and vote, got %+v %+v", out.Posts, out.Votes)
+		}
+		if !out.DiffReset || len(out.Submissions) != 0 {
+			t.Errorf("expected diff reset with no new submissions, got %v", out.Submissions)
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package state
+
+import (
+	"time"
+)
+
+// PublishPolicy determines how changes of a master board are batched into published roots.
+// Changes that are not published immediately are published on the compiler's interval.
+type PublishPolicy struct {
+	Interval     time.Duration // Interval in which compiler publishes changes.
+	MaxBatchSize int           // Publish immediately when this many changes are pending (unlimited if 0).
+	WhenIdle     bool          // Publish immediately when nothing was published within the last interval.
+}
+
+// PublishStats represents publishing metrics of a master board.
+// Latency is the duration between the first pending change of a batch and it's publication.
+type PublishStats struct {
+	Pending        int     `json:"pending"`          // Changes waiting to be published.
+	Publishes      uint64  `json:"publishes"`        // Number of published roots.
+	Changes        uint64  `json:"changes"`          // Number of published changes.
+	LastBatchSize  int     `json:"last_batch_size"`  // Changes in last published root.
+	MaxBatchSize   int     `json:"max_batch_size"`   // Most changes in a published root.
+	AvgBatchSize   float64 `json:"avg_batch_size"`   // Average changes per published root.
+	LastLatencyMS  int64   `json:"last_latency_ms"`  // Latency of last published root.
+	MaxLatencyMS   int64   `json:"max_latency_ms"`   // Highest latency of a published root.
+	AvgLatencyMS   int64   `json:"avg_latency_ms"`   // Average latency of published roots.
+	LastPublishTS  int64   `json:"last_publish_ts"`  // Time of last publication (unix nano).
+	PublishOnBatch uint64  `json:"publish_on_batch"` // Roots published as max batch size is reached.
+	PublishOnIdle  uint64  `json:"publish_on_idle"`  // Roots published as board is idle.
+}
+
+// publishBatch keeps track of pending changes and publishing metrics.
+type publishBatch struct {
+	pending      int
+	since        time.Time // Time of first pending change.
+	last         time.Time // Time of last publication.
+	totalLatency time.Duration
+	stats        PublishStats
+}
+
+// SetPublishPolicy sets how changes are batched into published roots.
+func (bi *BoardInstance) SetPublishPolicy(policy PublishPolicy) *BoardInstance {
+	bi.mux.Lock()
+	defer bi.mux.Unlock()
+
+	bi.policy = policy
+	return bi
+}
+
+// GetPublishStats obtains publishing metrics of the board.
+func (bi *BoardInstance) GetPublishStats() *PublishStats {
+	bi.mux.RLock()
+	defer bi.mux.RUnlock()
+
+	out := bi.batch.stats
+	out.Pending = bi.batch.pending
+	return &out
+}
+
+// queueChange records a change that is pending publication.
+// Should only be used when instance is locked.
+func (bi *BoardInstance) queueChange() {
+	if bi.batch.pending == 0 {
+		bi.batch.since = time.Now()
+	}
+	bi.batch.pending++
+}
+
+// recordPublish records the publication of pending changes.
+// Should only be used when instance is locked.
+func (bi *BoardInstance) recordPublish() {
+	var (
+		now     = time.Now()
+		b       = &bi.batch
+		latency = now.Sub(b.since)
+	)
+	if b.pending == 0 {
+		latency = 0
+	}
+	b.totalLatency += latency
+	b.last = now
+
+	s := &b.stats
+	s.Publishes++
+	s.Changes += uint64(b.pending)
+	s.LastBatchSize = b.pending
+	if b.pending > s.MaxBatchSize {
+		s.MaxBatchSize = b.pending
+	}
+	s.AvgBatchSize = float64(s.Changes) / float64(s.Publishes)
+	s.LastLatencyMS = int64(latency / time.Millisecond)
+	if s.LastLatencyMS > s.MaxLatencyMS {
+		s.MaxLatencyMS = s.LastLatencyMS
+	}
+	s.AvgLatencyMS = int64(b.totalLatency/time.Millisecond) / int64(s.Publishes)
+	s.LastPublishTS = now.UnixNano()
+
+	b.pending = 0
+}
+
+// publishIfDue publishes pending changes immediately if required by the publish policy.
+func (bi *BoardInstance) publishIfDue() {
+	bi.mux.Lock()
+	var (
+		onBatch = bi.policy.MaxBatchSize > 0 && bi.batch.pending >= bi.policy.MaxBatchSize
+		onIdle  = bi.policy.WhenIdle && bi.batch.pending > 0 && time.Since(bi.batch.last) >= bi.policy.Interval
+	)
+	switch {
+	case onBatch:
+		bi.batch.stats.PublishOnBatch++
+	case onIdle:
+		bi.batch.stats.PublishOnIdle++
+	}
+	bi.mux.Unlock()
+
+	if onBatch || onIdle {
+		if e := bi.PublishChanges(); e != nil {
+			bi.l.Println("failed to publish changes:", e)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package state
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/skycoin/bbs/src/misc/tag"
@@ -190,6 +191,97 @@ func TestBoardInstance_Init(t *testing.T) {
 	close()
 }
 
+func TestBoardInstance_WaitSeq(t *testing.T) {
+	const (
+		bSeed = "a"
+		uSeed = "b"
+	)
+	bi, quit := initInstance(t, bSeed)
+	defer quit()
+
+	t.Run("wakes_on_publish", func(t *testing.T) {
+		_, goal := addThread(t, bi, 0, []byte(uSeed))
+		go func() {
+			time.Sleep(time.Millisecond * 50)
+			if e := bi.PublishChanges(); e != nil {
+				t.Error("failed to publish changes:", e)
+			}
+		}()
+		start := time.Now()
+		if e := bi.WaitSeq(context.Background(), goal); e != nil {
+			t.Fatal(e)
+		}
+		if d := time.Since(start); d > time.Millisecond*500 {
+			t.Errorf("expected waiter to wake on publish, took %v", d)
+		}
+	})
+
+	t.Run("honours_context", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+		defer cancel()
+		if e := bi.WaitSeq(ctx, bi.GetSeq()+100); e != context.DeadlineExceeded {
+			t.Errorf("expected deadline exceeded, got %v", e)
+		}
+	})
+
+	t.Run("honours_timeout", func(t *testing.T) {
+		bi.SetWaitTimeout(time.Millisecond * 50)
+		defer bi.SetWaitTimeout(0)
+		if e := bi.WaitSeq(context.Background(), bi.GetSeq()+100); e != context.DeadlineExceeded {
+			t.Errorf("expected deadline exceeded, got %v", e)
+		}
+	})
+}
+
+func TestBoardInstance_SetPublishPolicy(t *testing.T) {
+	const (
+		bSeed = "a"
+		uSeed = "b"
+	)
+	bi, quit := initInstance(t, bSeed)
+	defer quit()
+
+	bi.SetPublishPolicy(PublishPolicy{
+		Interval:     time.Hour,
+		MaxBatchSize: 3,
+		WhenIdle:     true,
+	})
+
+	// Board is idle, so the first change is published immediately.
+	if _, goal := addThread(t, bi, 0, []byte(uSeed)); bi.GetSeq() < goal {
+		t.Error("expected change of idle board to be published immediately")
+	}
+
+	// Board is no longer idle, so changes are batched.
+	var goal uint64
+	for i := 1; i <= 2; i++ {
+		_, goal = addThread(t, bi, i, []byte(uSeed))
+	}
+	if bi.GetSeq() >= goal {
+		t.Error("expected changes of busy board to be batched")
+	}
+	if stats := bi.GetPublishStats(); stats.Pending != 2 {
+		t.Errorf("expected 2 pending changes, got %d", stats.Pending)
+	}
+
+	// Reaching the max batch size publishes immediately.
+	_, goal = addThread(t, bi, 3, []byte(uSeed))
+	if bi.GetSeq() < goal {
+		t.Error("expected changes to be published when max batch size is reached")
+	}
+
+	stats := bi.GetPublishStats()
+	if stats.Publishes != 2 || stats.Changes != 4 || stats.Pending != 0 {
+		t.Errorf("unexpected counts: %+v", stats)
+	}
+	if stats.LastBatchSize != 3 || stats.MaxBatchSize != 3 || stats.AvgBatchSize != 2 {
+		t.Errorf("unexpected batch sizes: %+v", stats)
+	}
+	if stats.PublishOnIdle != 1 || stats.PublishOnBatch != 1 {
+		t.Errorf("unexpected publish triggers: %+v", stats)
+	}
+}
+
 func TestBoardInstance_UpdateWithReceived(t *testing.T) {
 	const (
 		MessengerServerAddress = "[::]:11001"
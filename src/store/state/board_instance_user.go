@@ -0,0 +1,107 @@
+package state
+
+import (
+	"github.com/skycoin/bbs/src/misc/boo"
+	"github.com/skycoin/bbs/src/store/object"
+	"github.com/skycoin/cxo/skyobject"
+)
+
+// DefaultSubmissionsPageSize is the page size used for user submissions when none is specified.
+const DefaultSubmissionsPageSize = 50
+
+// UserSubmissionsIn represents the input required to obtain the submissions of a user.
+type UserSubmissionsIn struct {
+	UserPubKey string
+	Types      []object.ContentType // Types of submissions to include, empty includes all.
+	StartIndex int
+	PageSize   int
+	Reverse    bool // Whether to obtain the most recent submissions first.
+}
+
+// UserSubmissionsOut represents the submissions of a user.
+type UserSubmissionsOut struct {
+	BoardPubKey string               `json:"board_public_key"`
+	UserPubKey  string               `json:"user_public_key"`
+	TotalCount  int                  `json:"total_count"` // Number of submissions of included types.
+	StartIndex  int                  `json:"start_index"`
+	IsReversed  bool                 `json:"is_reversed"`
+	Submissions []*object.ContentRep `json:"submissions"`
+}
+
+// GetUserSubmissions obtains the threads, posts and votes a user has submitted to the board,
+// as recorded in the user's profile of the board's root. Quarantined content is excluded.
+func (bi *BoardInstance) GetUserSubmissions(in *UserSubmissionsIn) (*UserSubmissionsOut, error) {
+	if in.StartIndex < 0 {
+		return nil, boo.Newf(boo.InvalidInput, "invalid start index %d", in.StartIndex)
+	}
+	pageSize := in.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultSubmissionsPageSize
+	}
+	v := bi.Viewer()
+	if !v.HasUser(in.UserPubKey) {
+		return nil, boo.Newf(boo.NotFound, "user of public key '%s' is not found in board",
+			in.UserPubKey)
+	}
+
+	out := &UserSubmissionsOut{
+		UserPubKey:  in.UserPubKey,
+		StartIndex:  in.StartIndex,
+		IsReversed:  in.Reverse,
+		Submissions: make([]*object.ContentRep, 0),
+	}
+	var subs []*object.Content
+
+	e := bi.ViewPack(func(p *skyobject.Pack, h *Headers) error {
+		out.BoardPubKey = p.Root().Pub.Hex()
+		uapHash, ok := h.GetUserProfileHash(in.UserPubKey)
+		if !ok {
+			// User is only referenced by the votes of others.
+			return nil
+		}
+		pages, e := object.GetPages(p, &object.GetPagesIn{UsersPage: true})
+		if e != nil {
+			return e
+		}
+		uapElem, e := pages.UsersPage.Users.RefByHash(uapHash)
+		if e != nil {
+			return boo.WrapTypef(e, boo.InvalidRead,
+				"failed to obtain profile of user '%s'", in.UserPubKey)
+		}
+		uap, e := object.GetUserProfile(uapElem)
+		if e != nil {
+			return e
+		}
+		return uap.RangeSubmissions(func(i int, c *object.Content) error {
+			if hasContentType(in.Types, c.GetBody().Type) && !v.IsQuarantined(c.GetHeader().Hash) {
+				subs = append(subs, c)
+			}
+			return nil
+		})
+	})
+	if e != nil {
+		return nil, e
+	}
+
+	out.TotalCount = len(subs)
+	for i := in.StartIndex; i < len(subs) && len(out.Submissions) < pageSize; i++ {
+		j := i
+		if in.Reverse {
+			j = len(subs) - 1 - i
+		}
+		out.Submissions = append(out.Submissions, subs[j].ToRep())
+	}
+	return out, nil
+}
+
+func hasContentType(types []object.ContentType, t object.ContentType) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, v := range types {
+		if v == t {
+			return true
+		}
+	}
+	return false
+}
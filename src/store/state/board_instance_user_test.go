@@ -0,0 +1,110 @@
+package state
+
+import (
+	"github.com/skycoin/bbs/src/store/object"
+	"github.com/skycoin/skycoin/src/cipher"
+	"testing"
+)
+
+func TestBoardInstance_GetUserSubmissions(t *testing.T) {
+	const (
+		boardSeed = "a"
+		userSeed  = "b"
+	)
+
+	bi, quit := initInstance(t, boardSeed)
+	defer quit()
+
+	tHash, _ := addThread(t, bi, 0, []byte(userSeed))
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+	for i := 0; i < 3; i++ {
+		addPost(t, bi, tHash, i, []byte(userSeed))
+	}
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+
+	upk, _ := cipher.GenerateDeterministicKeyPair([]byte(userSeed))
+
+	get := func(t *testing.T, in *UserSubmissionsIn) *UserSubmissionsOut {
+		in.UserPubKey = upk.Hex()
+		out, e := bi.GetUserSubmissions(in)
+		if e != nil {
+			t.Fatal(e)
+		}
+		return out
+	}
+	body := func(rep *object.ContentRep) *object.Body {
+		return rep.Body.(*object.Body)
+	}
+
+	t.Run("all", func(t *testing.T) {
+		out := get(t, &UserSubmissionsIn{})
+		if out.TotalCount != 4 || len(out.Submissions) != 4 {
+			t.Fatalf("expected 4 submissions, got %d (total %d)",
+				len(out.Submissions), out.TotalCount)
+		}
+		if body(out.Submissions[0]).Type != object.V5ThreadType {
+			t.Error("expected first submission to be thread")
+		}
+	})
+
+	t.Run("types", func(t *testing.T) {
+		out := get(t, &UserSubmissionsIn{Types: []object.ContentType{object.V5PostType}})
+		if out.TotalCount != 3 {
+			t.Errorf("expected 3 posts, got %d", out.TotalCount)
+		}
+		for _, rep := range out.Submissions {
+			if body(rep).Type != object.V5PostType {
+				t.Errorf("unexpected submission type '%s'", body(rep).Type)
+			}
+		}
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		out := get(t, &UserSubmissionsIn{StartIndex: 1, PageSize: 2})
+		if out.TotalCount != 4 || len(out.Submissions) != 2 {
+			t.Fatalf("expected page of 2, got %d", len(out.Submissions))
+		}
+		if name := body(out.Submissions[0]).Name; name != "Post 0" {
+			t.Errorf("expected 'Post 0', got '%s'", name)
+		}
+
+		out = get(t, &UserSubmissionsIn{PageSize: 1, Reverse: true})
+		if name := body(out.Submissions[0]).Name; name != "Post 2" {
+			t.Errorf("expected most recent 'Post 2', got '%s'", name)
+		}
+	})
+
+	t.Run("quarantined", func(t *testing.T) {
+		hash := get(t, &UserSubmissionsIn{}).Submissions[1].Header.Hash
+		v := bi.Viewer()
+		unlock := v.lock()
+		v.c.quarantine[hash] = &QuarantinedContent{Hash: hash, Type: object.V5PostType}
+		unlock()
+		defer func() {
+			unlock := v.lock()
+			delete(v.c.quarantine, hash)
+			unlock()
+		}()
+
+		out := get(t, &UserSubmissionsIn{})
+		if out.TotalCount != 3 {
+			t.Fatalf("expected quarantined post to be excluded, got %d submissions", out.TotalCount)
+		}
+		for _, rep := range out.Submissions {
+			if rep.Header.Hash == hash {
+				t.Error("expected quarantined post to be excluded")
+			}
+		}
+	})
+
+	t.Run("unknown_user", func(t *testing.T) {
+		other, _ := cipher.GenerateDeterministicKeyPair([]byte("c"))
+		if _, e := bi.GetUserSubmissions(&UserSubmissionsIn{UserPubKey: other.Hex()}); e == nil {
+			t.Error("expected error for unknown user")
+		}
+	})
+}
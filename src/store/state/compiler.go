@@ -10,23 +10,31 @@ import (
 	"github.com/skycoin/skycoin/src/cipher"
 	"log"
 	"os"
+	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 )
 
 const (
-	LogPrefix = "COMPILER"
+	LogPrefix       = "COMPILER"
+	MaxTransferHops = 8 // Max number of transfers followed when resolving a board.
 )
 
 // RootWrap transports a cxo root.
 type RootWrap struct {
-	Done chan struct{}
+	Done chan error // Receives the result of compilation (buffered, or nil if not waited on).
 	Root *skyobject.Root
 }
 
 // CompilerConfig configure the Compiler.
 type CompilerConfig struct {
-	UpdateInterval *int // In seconds.
+	UpdateInterval  *int    // In seconds, interval in which changes of master boards are published.
+	MaxBatchSize    *int    // Max changes per published root before publishing immediately (unlimited if nil or 0).
+	PublishWhenIdle *bool   // Whether to publish changes immediately if nothing was published within the interval.
+	SnapshotDir     *string // Directory to save board snapshots (disabled if nil or empty).
+	WaitTimeout     *int    // In seconds, max time to wait for submissions to be published (default if nil or 0).
+	RemoveDeleted   *bool   // Whether to remove local data of remote boards that are deleted by their masters.
 }
 
 // Compiler compiles views for boards.
@@ -37,8 +45,10 @@ type Compiler struct {
 	node *node.Node
 	file *object.CXOFileManager
 
-	mux    sync.Mutex
-	boards map[cipher.PubKey]*BoardInstance
+	mux     sync.Mutex
+	boards  map[cipher.PubKey]*BoardInstance
+	workers map[cipher.PubKey]*boardWorker // Compiles roots and publishes changes per board.
+	events  *EventBus
 
 	newRoots chan RootWrap
 	quit     chan struct{}
@@ -58,6 +68,8 @@ func NewCompiler(
 		node:     node,
 		file:     file,
 		boards:   make(map[cipher.PubKey]*BoardInstance),
+		workers:  make(map[cipher.PubKey]*boardWorker),
+		events:   NewEventBus(),
 		newRoots: newRoots,
 		quit:     make(chan struct{}),
 	}
@@ -85,14 +97,17 @@ func (c *Compiler) updateLoop() {
 			c.publishAllMasters()
 
 		case rootWrap := <-c.newRoots:
-			c.updateSingle(rootWrap.Root)
-			select {
-			case rootWrap.Done <- struct{}{}:
-			default:
-			}
+			c.dispatch(rootWrap)
 
 		case <-c.quit:
-			for _, bi := range c.boards {
+			// Workers are closed outside of the lock as they may be waiting on it.
+			c.mux.Lock()
+			boards, workers := c.boards, c.workers
+			c.boards = make(map[cipher.PubKey]*BoardInstance)
+			c.workers = make(map[cipher.PubKey]*boardWorker)
+			c.mux.Unlock()
+			for pk, bi := range boards {
+				workers[pk].close()
 				bi.Close()
 			}
 			return
@@ -100,38 +115,70 @@ func (c *Compiler) updateLoop() {
 	}
 }
 
+// publishAllMasters requests the workers of master boards to publish changes.
 func (c *Compiler) publishAllMasters() {
 	c.file.RangeMasterSubs(func(pk cipher.PubKey, sk cipher.SecKey) {
-		bi := c.ensureBoard(pk)
-
-		if e := bi.PublishChanges(); e != nil {
-			c.l.Printf(" - [%s] Publish failed with error: %v", pk.Hex()[:5]+"...", e)
-		}
+		c.ensureBoard(pk).requestPublish()
 	})
 }
 
-func (c *Compiler) updateSingle(root *skyobject.Root) {
-
-	isRemote := c.file.HasRemoteSub(root.Pub)
-	sk, isMaster := c.file.GetMasterSubSecKey(root.Pub)
-
+// dispatch hands a received root to the worker of it's board.
+// It should not block, so that roots of other boards are not held up.
+func (c *Compiler) dispatch(rw RootWrap) {
+	var (
+		root     = rw.Root
+		isRemote = c.file.HasRemoteSub(root.Pub)
+		isMaster = c.file.HasMasterSub(root.Pub)
+	)
 	if !isRemote && !isMaster {
+		finishAll([]chan error{rw.Done}, nil)
 		return
 	}
 
-	bi := c.ensureBoard(root.Pub)
+	w := c.ensureBoard(root.Pub)
 
 	if root.IsFull == false {
 		c.l.Printf("received root '%s' is not full, returning.", root.Pub.Hex()[:5]+"...")
+		finishAll([]chan error{rw.Done}, nil)
 		return
 	}
 
+	w.pushRoot(rw)
+}
+
+// compile updates the board instance with the received root.
+// Returns false if the root is not compiled.
+func (c *Compiler) compile(bi *BoardInstance, root *skyobject.Root) (bool, error) {
+
+	isRemote := c.file.HasRemoteSub(root.Pub)
+	sk, isMaster := c.file.GetMasterSubSecKey(root.Pub)
+
+	if !isRemote && !isMaster {
+		return false, nil
+	}
+
 	if isMaster && bi.needPublish.Value() == true {
-		return
+		return false, nil
 	}
 
 	c.l.Printf("compiling '%s' : remote(%v) master(%v)", root.Pub.Hex()[:5]+"...", isRemote, isMaster)
-	bi.UpdateWithReceived(root, sk)
+	e := bi.UpdateWithReceived(root, sk)
+
+	if isRemote && bi.GetDeletion() != nil && c.c.RemoveDeleted != nil && *c.c.RemoveDeleted {
+		// Removal stops the worker running this, hence it is done separately.
+		go c.removeDeleted(root.Pub)
+	}
+	return true, e
+}
+
+// removeDeleted removes a remote board that is deleted by it's master.
+func (c *Compiler) removeDeleted(pk cipher.PubKey) {
+	c.l.Printf("removing deleted board '%s'", pk.Hex()[:5]+"...")
+	if e := c.file.RemoveSub(pk); e != nil {
+		c.l.Println(" - failed to remove subscription:", e)
+	}
+	c.DeleteBoard(pk)
+	c.node.DelFeed(pk)
 }
 
 // EnsureSubmissionKeys ranges through masters and ensures that their specified
@@ -150,15 +197,20 @@ func (c *Compiler) EnsureSubmissionKeys(subKeys []*object.MessengerSubKeyTranspo
 
 func (c *Compiler) DeleteBoard(bpk cipher.PubKey) {
 	c.mux.Lock()
-	defer c.mux.Unlock()
 
 	bi, has := c.boards[bpk]
 	if !has {
+		c.mux.Unlock()
 		return
 	}
+	w := c.workers[bpk]
+	delete(c.boards, bpk)
+	delete(c.workers, bpk)
+	c.mux.Unlock()
 
+	w.close()
+	bi.DiscardSnapshot()
 	bi.Close()
-	delete(c.boards, bpk)
 }
 
 func (c *Compiler) GetBoard(pk cipher.PubKey) (*BoardInstance, error) {
@@ -174,21 +226,48 @@ func (c *Compiler) GetBoard(pk cipher.PubKey) (*BoardInstance, error) {
 	case bi.IsReceived() == false:
 		return nil, boo.Newf(boo.NotFound,
 			"board '%s' has not been received", pk.Hex()[:5]+"...")
+
+	case bi.GetDeletion() != nil:
+		return nil, ErrBoardDeleted(pk, bi.GetDeletion())
 	}
 
 	return bi, nil
 }
 
+// ResolveBoard obtains the board instance of given public key, following boards
+// that are transferred to new keypairs to their successors.
+func (c *Compiler) ResolveBoard(pk cipher.PubKey) (*BoardInstance, error) {
+	for hops := 0; ; hops++ {
+		bi, e := c.GetBoard(pk)
+		if e == nil || hops == MaxTransferHops {
+			return bi, e
+		}
+		c.mux.Lock()
+		old, ok := c.boards[pk]
+		c.mux.Unlock()
+		if !ok {
+			return nil, e
+		}
+		notice := old.GetDeletion()
+		if notice == nil || notice.Transfer == nil {
+			return nil, e
+		}
+		if pk, e = notice.Transfer.Verify(pk); e != nil {
+			return nil, e
+		}
+	}
+}
+
 func (c *Compiler) UpdateBoard(root *skyobject.Root) {
 	c.newRoots <- RootWrap{Root: root}
 }
 
 func (c *Compiler) UpdateBoardWithContext(ctx context.Context, root *skyobject.Root) error {
-	done := make(chan struct{})
+	done := make(chan error, 1)
 	c.newRoots <- RootWrap{Root: root, Done: done}
 	select {
-	case <-done:
-		return nil
+	case e := <-done:
+		return e
 	case <-ctx.Done():
 		return ctx.Err()
 	}
@@ -211,6 +290,34 @@ func (c *Compiler) GetMasterSummaries() []*object.BoardSummaryWrap {
 	return out
 }
 
+// GetStats obtains metrics of the compiler and it's board workers.
+func (c *Compiler) GetStats() *CompilerStats {
+	c.mux.Lock()
+	workers := make([]*boardWorker, 0, len(c.workers))
+	for _, w := range c.workers {
+		workers = append(workers, w)
+	}
+	c.mux.Unlock()
+
+	out := &CompilerStats{
+		Queued:   len(c.newRoots),
+		QueueCap: cap(c.newRoots),
+		Workers:  make([]*WorkerStats, len(workers)),
+	}
+	for i, w := range workers {
+		out.Workers[i] = w.getStats()
+	}
+	sort.Slice(out.Workers, func(i, j int) bool {
+		return out.Workers[i].BoardPubKey < out.Workers[j].BoardPubKey
+	})
+	return out
+}
+
+// Events obtains the event bus in which changes of compiled boards are broadcast to.
+func (c *Compiler) Events() *EventBus {
+	return c.events
+}
+
 func (c *Compiler) RangeMasterSubs(action object.MasterSubAction) error {
 	return c.file.RangeMasterSubs(action)
 }
@@ -219,15 +326,49 @@ func (c *Compiler) RangeMasterSubs(action object.MasterSubAction) error {
 	<<< HELPER FUNCTIONS >>>
 */
 
-func (c *Compiler) ensureBoard(pk cipher.PubKey) *BoardInstance {
+// ensureBoard ensures the board instance and worker of the board exists.
+func (c *Compiler) ensureBoard(pk cipher.PubKey) *boardWorker {
 	c.mux.Lock()
 	defer c.mux.Unlock()
 
 	bi, has := c.boards[pk]
 	if !has {
-		bi = new(BoardInstance).Init(c.node, pk)
+		bi = new(BoardInstance).Init(c.node, pk).SetEventBus(c.events)
+		if c.c.WaitTimeout != nil {
+			bi.SetWaitTimeout(time.Second * time.Duration(*c.c.WaitTimeout))
+		}
+		bi.SetPublishPolicy(c.publishPolicy())
+		if path := c.snapshotPath(pk); path != "" {
+			bi.EnableSnapshot(path)
+		}
 		c.boards[pk] = bi
+		c.workers[pk] = newBoardWorker(c, pk, bi)
+		c.events.Publish(NewBoardEvent(EventBoardReceived, pk, 0))
 	}
 	bi.SetReceived()
-	return bi
+	return c.workers[pk]
+}
+
+func (c *Compiler) publishPolicy() PublishPolicy {
+	policy := PublishPolicy{
+		Interval: time.Second * time.Duration(*c.c.UpdateInterval),
+	}
+	if c.c.MaxBatchSize != nil {
+		policy.MaxBatchSize = *c.c.MaxBatchSize
+	}
+	if c.c.PublishWhenIdle != nil {
+		policy.WhenIdle = *c.c.PublishWhenIdle
+	}
+	return policy
+}
+
+func (c *Compiler) snapshotPath(pk cipher.PubKey) string {
+	if c.c.SnapshotDir == nil || *c.c.SnapshotDir == "" {
+		return ""
+	}
+	if e := os.MkdirAll(*c.c.SnapshotDir, os.FileMode(0700)); e != nil {
+		c.l.Println("failed to create snapshot directory:", e)
+		return ""
+	}
+	return filepath.Join(*c.c.SnapshotDir, pk.Hex()+SnapshotFileExt)
 }
@@ -0,0 +1,177 @@
+package state
+
+import (
+	"github.com/skycoin/bbs/src/misc/boo"
+	"github.com/skycoin/cxo/skyobject"
+	"github.com/skycoin/skycoin/src/cipher"
+	"sync"
+	"time"
+)
+
+// ErrWorkerClosed is received by waiters of roots that were pending when the worker closed.
+var ErrWorkerClosed = boo.New(boo.NotAllowed, "board worker closed before root was compiled")
+
+// WorkerStats represents metrics of a board's compile worker.
+type WorkerStats struct {
+	BoardPubKey   string `json:"board_public_key"`
+	Received      uint64 `json:"received"`        // Full roots queued for compilation.
+	Coalesced     uint64 `json:"coalesced"`       // Roots skipped in favour of newer roots.
+	Compiled      uint64 `json:"compiled"`        // Roots compiled.
+	Failed        uint64 `json:"failed"`          // Roots that failed to compile.
+	Publishes     uint64 `json:"publishes"`       // Publish runs of master board.
+	Pending       bool   `json:"pending"`         // Whether a root is waiting to be compiled.
+	LastSeq       uint64 `json:"last_seq"`        // Sequence of last compiled root.
+	LastCompileMS int64  `json:"last_compile_ms"` // Duration of last compilation.
+	MaxCompileMS  int64  `json:"max_compile_ms"`  // Longest duration of a compilation.
+}
+
+// CompilerStats represents metrics of the compiler.
+type CompilerStats struct {
+	Queued   int            `json:"queued"`    // Roots waiting to be dispatched to workers.
+	QueueCap int            `json:"queue_cap"` // Capacity of dispatch queue.
+	Workers  []*WorkerStats `json:"workers"`
+}
+
+// boardWorker compiles the roots and publishes the changes of a single board.
+// Pending roots are coalesced, so at most one root waits while another compiles.
+type boardWorker struct {
+	c  *Compiler
+	bi *BoardInstance
+
+	mux     sync.Mutex
+	root    *skyobject.Root // Newest pending root (nil if none).
+	done    []chan error    // Receive the result when the pending root (or a newer one) is compiled.
+	publish bool            // Whether publishing is pending.
+	stats   WorkerStats
+
+	wake chan struct{}
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newBoardWorker(c *Compiler, pk cipher.PubKey, bi *BoardInstance) *boardWorker {
+	w := &boardWorker{
+		c:     c,
+		bi:    bi,
+		stats: WorkerStats{BoardPubKey: pk.Hex()},
+		wake:  make(chan struct{}, 1),
+		quit:  make(chan struct{}),
+	}
+	w.wg.Add(1)
+	go w.loop()
+	return w
+}
+
+// close stops the worker, waiting for current work to finish.
+func (w *boardWorker) close() {
+	close(w.quit)
+	w.wg.Wait()
+}
+
+// pushRoot queues a full root for compilation, replacing any older pending root.
+func (w *boardWorker) pushRoot(rw RootWrap) {
+	w.mux.Lock()
+	w.stats.Received++
+	if w.root != nil {
+		w.stats.Coalesced++
+	}
+	if w.root == nil || rw.Root.Seq >= w.root.Seq {
+		w.root = rw.Root
+	}
+	if rw.Done != nil {
+		w.done = append(w.done, rw.Done)
+	}
+	w.mux.Unlock()
+	w.signal()
+}
+
+// requestPublish queues publishing of changes.
+func (w *boardWorker) requestPublish() {
+	w.mux.Lock()
+	w.publish = true
+	w.mux.Unlock()
+	w.signal()
+}
+
+func (w *boardWorker) signal() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (w *boardWorker) getStats() *WorkerStats {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	out := w.stats
+	out.Pending = w.root != nil
+	return &out
+}
+
+func (w *boardWorker) loop() {
+	defer w.wg.Done()
+	for {
+		select {
+		case <-w.wake:
+			w.work()
+		case <-w.quit:
+			w.mux.Lock()
+			finishAll(w.done, ErrWorkerClosed)
+			w.root, w.done = nil, nil
+			w.mux.Unlock()
+			return
+		}
+	}
+}
+
+func (w *boardWorker) work() {
+	w.mux.Lock()
+	root, done, publish := w.root, w.done, w.publish
+	w.root, w.done, w.publish = nil, nil, false
+	w.mux.Unlock()
+
+	var e error
+	defer func() { finishAll(done, e) }()
+
+	if publish {
+		if e := w.bi.PublishChanges(); e != nil {
+			w.c.l.Printf(" - [%s] Publish failed with error: %v", w.stats.BoardPubKey[:5]+"...", e)
+		}
+		w.mux.Lock()
+		w.stats.Publishes++
+		w.mux.Unlock()
+	}
+	if root == nil {
+		return
+	}
+
+	start := time.Now()
+	var compiled bool
+	compiled, e = w.c.compile(w.bi, root)
+	if !compiled {
+		return
+	}
+	d := int64(time.Since(start) / time.Millisecond)
+
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	if e != nil {
+		w.stats.Failed++
+		return
+	}
+	w.stats.Compiled++
+	w.stats.LastSeq = root.Seq
+	w.stats.LastCompileMS = d
+	if d > w.stats.MaxCompileMS {
+		w.stats.MaxCompileMS = d
+	}
+}
+
+// finishAll sends the result to waiters, whose channels should be buffered.
+func finishAll(done []chan error, e error) {
+	for _, d := range done {
+		if d != nil {
+			d <- e
+		}
+	}
+}
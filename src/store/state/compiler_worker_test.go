@@ -0,0 +1,46 @@
+package state
+
+import (
+	"github.com/skycoin/cxo/skyobject"
+	"testing"
+)
+
+func TestBoardWorker_pushRoot(t *testing.T) {
+	w := &boardWorker{
+		wake: make(chan struct{}, 1),
+		quit: make(chan struct{}),
+	}
+	var done []chan error
+	for _, seq := range []uint64{1, 3, 2} {
+		d := make(chan error, 1)
+		done = append(done, d)
+		w.pushRoot(RootWrap{Root: &skyobject.Root{Seq: seq}, Done: d})
+	}
+
+	stats := w.getStats()
+	if stats.Received != 3 || stats.Coalesced != 2 || !stats.Pending {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	if w.root.Seq != 3 {
+		t.Errorf("expected newest root (seq 3) to be pending, got seq %d", w.root.Seq)
+	}
+	if len(w.wake) != 1 {
+		t.Error("expected worker to be woken once")
+	}
+
+	// Closing the worker releases all waiters with an error, as nothing was compiled.
+	<-w.wake
+	w.wg.Add(1)
+	go w.loop()
+	w.close()
+	for i, d := range done {
+		select {
+		case e := <-d:
+			if e != ErrWorkerClosed {
+				t.Errorf("expected waiter %d to receive ErrWorkerClosed, got: %v", i, e)
+			}
+		default:
+			t.Errorf("expected waiter %d to be released", i)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package state
+
+import (
+	"github.com/skycoin/bbs/src/misc/boo"
+	"github.com/skycoin/bbs/src/store/object"
+	"github.com/skycoin/skycoin/src/cipher"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// DefaultEventBufferSize is the number of events a subscription buffers
+	// before events are dropped.
+	DefaultEventBufferSize = 128
+
+	// EventHistorySize is the max number of events kept per board for replay.
+	EventHistorySize = 1024
+)
+
+// EventType determines the type of an event.
+type EventType string
+
+const (
+	EventBoardReceived = EventType("board_received") // Board instance is created for a received root.
+	EventBoardReady    = EventType("board_ready")    // Views of board are compiled for the first time.
+	EventBoardUpdated  = EventType("board_updated")  // Views of board are compiled to a new root sequence.
+	EventBoardReset    = EventType("board_reset")    // Views of board are recompiled as submissions were reset.
+	EventBoardDeleted  = EventType("board_deleted")  // Board is deleted by it's master.
+	EventThreadCreated = EventType("thread_created") // A thread is compiled.
+	EventPostCreated   = EventType("post_created")   // A post is compiled.
+	EventVoteChanged   = EventType("vote_changed")   // A thread, post or user vote is compiled.
+)
+
+// GetEventType obtains an event type from string.
+func GetEventType(v string) (EventType, error) {
+	switch t := EventType(v); t {
+	case EventBoardReceived, EventBoardReady, EventBoardUpdated, EventBoardReset, EventBoardDeleted,
+		EventThreadCreated, EventPostCreated, EventVoteChanged:
+		return t, nil
+	}
+	return "", boo.Newf(boo.InvalidInput, "invalid event type '%s'", v)
+}
+
+/*
+	<<< EVENT >>>
+*/
+
+// Event represents a change of compiled views of a board.
+type Event struct {
+	Type        EventType          `json:"type"`
+	BoardPubKey string             `json:"board_public_key"`
+	Seq         uint64             `json:"seq"`                   // Root sequence of which the change is compiled from.
+	ThreadHash  string             `json:"thread_hash,omitempty"` // Thread of the post or vote (if any).
+	Ref         string             `json:"ref,omitempty"`         // Hash of voted content, or public key of voted user.
+	Content     *object.ContentRep `json:"content,omitempty"`     // Thread, post or vote.
+
+	Deletion *object.BoardDeletion `json:"deletion,omitempty"` // Deletion notice of deleted board.
+}
+
+// NewBoardEvent creates an event that only concerns the board.
+func NewBoardEvent(t EventType, bpk cipher.PubKey, seq uint64) *Event {
+	return &Event{
+		Type:        t,
+		BoardPubKey: bpk.Hex(),
+		Seq:         seq,
+	}
+}
+
+// NewContentEvent creates an event from newly compiled content.
+// 'threadOf' obtains the thread hash of a post, so that post votes can be filtered by thread.
+// Returns nil if the content is not of an event type.
+func NewContentEvent(bpk cipher.PubKey, seq uint64, c *object.Content, threadOf func(pHash string) string) *Event {
+	body := c.GetBody()
+	out := &Event{
+		BoardPubKey: bpk.Hex(),
+		Seq:         seq,
+		Content:     c.ToRep(),
+	}
+	switch body.Type {
+	case object.V5ThreadType:
+		out.Type = EventThreadCreated
+		out.ThreadHash = out.Content.Header.Hash
+	case object.V5PostType:
+		out.Type = EventPostCreated
+		out.ThreadHash = body.OfThread
+	case object.V5ThreadVoteType:
+		out.Type = EventVoteChanged
+		out.ThreadHash, out.Ref = body.OfThread, body.OfThread
+	case object.V5PostVoteType:
+		out.Type = EventVoteChanged
+		out.ThreadHash, out.Ref = threadOf(body.OfPost), body.OfPost
+	case object.V5UserVoteType:
+		out.Type = EventVoteChanged
+		out.Ref = body.OfUser
+	default:
+		return nil
+	}
+	return out
+}
+
+/*
+	<<< FILTER >>>
+*/
+
+// EventFilter determines what events a subscription receives.
+// Empty fields match all events.
+type EventFilter struct {
+	Boards  []string    // Public keys of boards.
+	Types   []EventType // Types of events.
+	Threads []string    // Hashes of threads.
+	MinSeq  uint64      // Events of lower root sequence are excluded.
+	Updates bool        // Whether board updates pass the thread and type filters.
+}
+
+// Match determines whether the event passes the filter.
+func (f *EventFilter) Match(e *Event) bool {
+	if f == nil {
+		return true
+	}
+	if e.Seq < f.MinSeq {
+		return false
+	}
+	if len(f.Boards) > 0 && !hasString(f.Boards, e.BoardPubKey) {
+		return false
+	}
+	if f.Updates && e.Type == EventBoardUpdated {
+		return true
+	}
+	if len(f.Threads) > 0 && !hasString(f.Threads, e.ThreadHash) {
+		return false
+	}
+	if len(f.Types) > 0 {
+		for _, t := range f.Types {
+			if t == e.Type {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func hasString(list []string, v string) bool {
+	for _, elem := range list {
+		if elem == v {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+	<<< BUS >>>
+*/
+
+// EventBus distributes events to subscriptions, and keeps recent events of each board for replay.
+// Publishing never blocks; events are dropped for subscriptions that have a full buffer.
+type EventBus struct {
+	mux     sync.RWMutex
+	subs    map[uint64]*EventSubscription
+	nextID  uint64
+	history map[string]*eventHistory // key (board public key)
+}
+
+// eventHistory holds recent events of a board for replay.
+type eventHistory struct {
+	covered uint64 // Events of all root sequences after this are in history.
+	events  []*Event
+}
+
+func (h *eventHistory) add(e *Event) {
+	switch e.Type {
+	case EventBoardReady, EventBoardReset:
+		// Views are compiled from scratch, previous events no longer apply.
+		h.covered, h.events = e.Seq, h.events[:0]
+	}
+	h.events = append(h.events, e)
+	if over := len(h.events) - EventHistorySize; over > 0 {
+		if seq := h.events[over-1].Seq; seq > h.covered {
+			h.covered = seq
+		}
+		h.events = append(h.events[:0], h.events[over:]...)
+	}
+}
+
+// NewEventBus creates a new EventBus.
+func NewEventBus() *EventBus {
+	return &EventBus{
+		subs:    make(map[uint64]*EventSubscription),
+		history: make(map[string]*eventHistory),
+	}
+}
+
+// Subscribe registers a subscription that receives events that match the filter.
+// A buffer size of 0 or less uses 'DefaultEventBufferSize'.
+func (b *EventBus) Subscribe(filter *EventFilter, bufSize int) *EventSubscription {
+	if bufSize <= 0 {
+		bufSize = DefaultEventBufferSize
+	}
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	return b.subscribe(filter, bufSize)
+}
+
+// SubscribeFrom registers a subscription, and also obtains recent events of
+// the board that are of a root sequence after 'since' (and match the filter).
+// Returns false if the board's history does not reach back to 'since', in which
+// case the subscriber should obtain views of the board anew.
+func (b *EventBus) SubscribeFrom(filter *EventFilter, bufSize int, bpk string, since uint64) (*EventSubscription, []*Event, bool) {
+	if bufSize <= 0 {
+		bufSize = DefaultEventBufferSize
+	}
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	var (
+		replay   []*Event
+		complete bool
+	)
+	if h, ok := b.history[bpk]; ok {
+		complete = since >= h.covered
+		for _, e := range h.events {
+			if e.Seq > since && filter.Match(e) {
+				replay = append(replay, e)
+			}
+		}
+	}
+	return b.subscribe(filter, bufSize), replay, complete
+}
+
+// subscribe should only be used when bus is locked.
+func (b *EventBus) subscribe(filter *EventFilter, bufSize int) *EventSubscription {
+	b.nextID++
+	sub := &EventSubscription{
+		id:     b.nextID,
+		bus:    b,
+		filter: filter,
+		c:      make(chan *Event, bufSize),
+	}
+	b.subs[sub.id] = sub
+	return sub
+}
+
+// Publish sends events to all matching subscriptions.
+func (b *EventBus) Publish(events ...*Event) {
+	if b == nil {
+		return
+	}
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	for _, e := range events {
+		if e == nil || e.Seq == 0 {
+			continue
+		}
+		h, ok := b.history[e.BoardPubKey]
+		if !ok {
+			h = &eventHistory{covered: e.Seq}
+			b.history[e.BoardPubKey] = h
+		}
+		h.add(e)
+	}
+	for _, sub := range b.subs {
+		for _, e := range events {
+			if e == nil || !sub.filter.Match(e) {
+				continue
+			}
+			select {
+			case sub.c <- e:
+			default:
+				atomic.AddUint64(&sub.dropped, 1)
+			}
+		}
+	}
+}
+
+// Count obtains the number of subscriptions.
+func (b *EventBus) Count() int {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+	return len(b.subs)
+}
+
+func (b *EventBus) unsubscribe(sub *EventSubscription) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	if _, ok := b.subs[sub.id]; ok {
+		delete(b.subs, sub.id)
+		close(sub.c)
+	}
+}
+
+// EventSubscription receives events from an EventBus.
+type EventSubscription struct {
+	dropped uint64 // Accessed atomically; kept first for 64-bit alignment.
+	id      uint64
+	bus     *EventBus
+	filter  *EventFilter
+	c       chan *Event
+}
+
+// C obtains the channel in which events are received.
+// The channel is closed when the subscription is closed.
+func (s *EventSubscription) C() <-chan *Event {
+	return s.c
+}
+
+// Dropped obtains the number of events dropped due to a full buffer.
+func (s *EventSubscription) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Close unregisters the subscription from the bus.
+func (s *EventSubscription) Close() {
+	s.bus.unsubscribe(s)
+}
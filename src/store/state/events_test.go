@@ -0,0 +1,181 @@
+package state
+
+import (
+	"github.com/skycoin/bbs/src/store/object"
+	"github.com/skycoin/skycoin/src/cipher"
+	"testing"
+)
+
+func TestEventFilter_Match(t *testing.T) {
+	e := &Event{Type: EventPostCreated, BoardPubKey: "b", ThreadHash: "t", Seq: 5}
+
+	cases := []struct {
+		name     string
+		filter   *EventFilter
+		expected bool
+	}{
+		{"nil", nil, true},
+		{"empty", &EventFilter{}, true},
+		{"board", &EventFilter{Boards: []string{"a", "b"}}, true},
+		{"other_board", &EventFilter{Boards: []string{"a"}}, false},
+		{"type", &EventFilter{Types: []EventType{EventThreadCreated, EventPostCreated}}, true},
+		{"other_type", &EventFilter{Types: []EventType{EventVoteChanged}}, false},
+		{"thread", &EventFilter{Threads: []string{"t"}}, true},
+		{"other_thread", &EventFilter{Threads: []string{"x"}}, false},
+		{"min_seq", &EventFilter{MinSeq: 5}, true},
+		{"above_min_seq", &EventFilter{MinSeq: 6}, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.filter.Match(e); got != c.expected {
+				t.Errorf("got %v, expected %v", got, c.expected)
+			}
+		})
+	}
+
+	t.Run("updates", func(t *testing.T) {
+		update := &Event{Type: EventBoardUpdated, BoardPubKey: "b", Seq: 5}
+		filter := &EventFilter{Types: []EventType{EventPostCreated}, Threads: []string{"t"}}
+		if filter.Match(update) {
+			t.Error("expected board update to be filtered out")
+		}
+		filter.Updates = true
+		if !filter.Match(update) {
+			t.Error("expected board update to pass the filter")
+		}
+		if filter.Boards = []string{"a"}; filter.Match(update) {
+			t.Error("expected board update of other board to be filtered out")
+		}
+	})
+}
+
+func TestNewContentEvent_PostVote(t *testing.T) {
+	bpk, _ := cipher.GenerateDeterministicKeyPair([]byte("a"))
+	c := new(object.Content)
+	c.SetHeader(&object.ContentHeaderData{})
+	c.SetBody(&object.Body{
+		Type:    object.V5PostVoteType,
+		OfBoard: bpk.Hex(),
+		OfPost:  "p",
+		Value:   +1,
+	})
+	threadOf := func(pHash string) string {
+		if pHash == "p" {
+			return "t"
+		}
+		return ""
+	}
+	e := NewContentEvent(bpk, 1, c, threadOf)
+	if e == nil || e.Type != EventVoteChanged || e.Ref != "p" {
+		t.Fatalf("expected vote event of post 'p', got %+v", e)
+	}
+	if !(&EventFilter{Threads: []string{"t"}}).Match(e) {
+		t.Error("expected post vote event to match filter of it's thread")
+	}
+}
+
+func TestBoardInstance_broadcastChanges(t *testing.T) {
+	const (
+		boardSeed = "a"
+		userSeed  = "b"
+	)
+
+	bi, quit := initInstance(t, boardSeed)
+	defer quit()
+
+	bus := NewEventBus()
+	bi.SetEventBus(bus)
+
+	sub := bus.Subscribe(&EventFilter{
+		Types: []EventType{EventThreadCreated, EventBoardUpdated},
+	}, 0)
+	defer sub.Close()
+
+	tHash, _ := addThread(t, bi, 0, []byte(userSeed))
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+
+	expected := []EventType{EventThreadCreated, EventBoardUpdated}
+	for i, eType := range expected {
+		select {
+		case e := <-sub.C():
+			if e.Type != eType {
+				t.Fatalf("[%d] got event '%s', expected '%s'", i, e.Type, eType)
+			}
+			if e.Seq != bi.GetSeq() {
+				t.Errorf("[%d] got seq %d, expected %d", i, e.Seq, bi.GetSeq())
+			}
+			if e.Type == EventThreadCreated && e.ThreadHash != tHash.Hex() {
+				t.Errorf("got thread '%s', expected '%s'", e.ThreadHash, tHash.Hex())
+			}
+		default:
+			t.Fatalf("[%d] expected event '%s'", i, eType)
+		}
+	}
+
+	sub.Close()
+	if bus.Count() != 0 {
+		t.Error("expected subscription to be removed")
+	}
+	if _, ok := <-sub.C(); ok {
+		t.Error("expected subscription channel to be closed")
+	}
+}
+
+func TestEventBus_SubscribeFrom(t *testing.T) {
+	bus := NewEventBus()
+	bus.Publish(
+		&Event{Type: EventBoardReady, BoardPubKey: "b", Seq: 2},
+		&Event{Type: EventThreadCreated, BoardPubKey: "b", Seq: 3},
+		&Event{Type: EventBoardUpdated, BoardPubKey: "b", Seq: 3},
+		&Event{Type: EventPostCreated, BoardPubKey: "b", Seq: 4},
+		&Event{Type: EventBoardUpdated, BoardPubKey: "b", Seq: 4},
+	)
+
+	t.Run("resume", func(t *testing.T) {
+		sub, replay, complete := bus.SubscribeFrom(&EventFilter{MinSeq: 4}, 0, "b", 3)
+		defer sub.Close()
+		if !complete {
+			t.Error("expected replay to be complete")
+		}
+		if len(replay) != 2 || replay[0].Type != EventPostCreated {
+			t.Errorf("unexpected replay: %v", replay)
+		}
+	})
+
+	t.Run("before_ready", func(t *testing.T) {
+		sub, _, complete := bus.SubscribeFrom(nil, 0, "b", 1)
+		defer sub.Close()
+		if complete {
+			t.Error("expected replay to be incomplete")
+		}
+	})
+
+	t.Run("unknown_board", func(t *testing.T) {
+		sub, replay, complete := bus.SubscribeFrom(nil, 0, "x", 1)
+		defer sub.Close()
+		if complete || len(replay) != 0 {
+			t.Error("expected empty and incomplete replay")
+		}
+	})
+
+	t.Run("trimmed", func(t *testing.T) {
+		for i := 0; i < EventHistorySize; i++ {
+			bus.Publish(&Event{Type: EventBoardUpdated, BoardPubKey: "b", Seq: uint64(5 + i)})
+		}
+		sub, _, complete := bus.SubscribeFrom(nil, 0, "b", 3)
+		defer sub.Close()
+		if complete {
+			t.Error("expected replay to be incomplete")
+		}
+		sub, replay, complete := bus.SubscribeFrom(nil, 0, "b", 4)
+		defer sub.Close()
+		if !complete {
+			t.Error("expected replay to be complete")
+		}
+		if len(replay) != EventHistorySize {
+			t.Errorf("got %d replayed events, expected %d", len(replay), EventHistorySize)
+		}
+	})
+}
@@ -0,0 +1,358 @@
+package state
+
+import (
+	"github.com/skycoin/bbs/src/misc/boo"
+	"github.com/skycoin/bbs/src/misc/inform"
+	"github.com/skycoin/bbs/src/misc/typ"
+	"github.com/skycoin/bbs/src/misc/typ/paginatedtypes"
+	"github.com/skycoin/bbs/src/store/object"
+	"github.com/skycoin/cxo/skyobject"
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/util/file"
+	"math"
+	"os"
+)
+
+const (
+	// SnapshotVersion is to be incremented whenever the snapshot format changes.
+	SnapshotVersion = 3
+
+	// SnapshotFileExt is the file extension of board snapshots.
+	SnapshotFileExt = ".snapshot.json"
+)
+
+/*
+	<<< SNAPSHOT >>>
+*/
+
+// Snapshot holds the compiled state of a board at a given root sequence.
+// It allows a board to be made available on startup without walking all
+// content of the board through CXO.
+type Snapshot struct {
+	Version        int    `json:"version"`
+	PubKey         string `json:"public_key"`
+	RootSeq        uint64 `json:"root_seq"`
+	RootHash       string `json:"root_hash"`
+	Total          int    `json:"total"`                     // Number of submissions in diff page.
+	LastSubmission string `json:"last_submission,omitempty"` // Hash of last submission in diff page.
+
+	Indexer   *IndexerSnapshot   `json:"indexer"`
+	Container *ContainerSnapshot `json:"container"`
+	Headers   *HeadersSnapshot   `json:"headers"`
+}
+
+// IndexerSnapshot is the serializable form of an Indexer.
+type IndexerSnapshot struct {
+	Board         string                 `json:"board"`
+	Threads       []string               `json:"threads"`
+	ThreadRanks   map[string]*ThreadRank `json:"thread_ranks"`
+	PostsOfThread map[string][]string    `json:"posts_of_thread"`
+	Users         []string               `json:"users"`
+}
+
+// ContainerSnapshot is the serializable form of a Container.
+type ContainerSnapshot struct {
+	Content    map[string]*ContentSnapshot    `json:"content"`
+	Votes      map[string]*VotesRep           `json:"votes"`
+	Profiles   map[string]*Profile            `json:"profiles"`
+	Quarantine map[string]*QuarantinedContent `json:"quarantine"`
+}
+
+// ContentSnapshot is the serializable form of a content representation.
+type ContentSnapshot struct {
+	Header *object.ContentHeaderData `json:"header"`
+	Body   *object.Body              `json:"body"`
+}
+
+// HeadersSnapshot is the serializable form of Headers.
+type HeadersSnapshot struct {
+	Threads map[string]string `json:"threads"` // key(thread content hash), value(thread page hash)
+	Users   map[string]string `json:"users"`   // key(user's public key), value(user profile hash)
+}
+
+// NewSnapshot generates a snapshot of the board's headers and views at the pack's root sequence.
+func NewSnapshot(p *skyobject.Pack, h *Headers, v *Viewer) (*Snapshot, error) {
+	if h == nil || v == nil {
+		return nil, ErrViewerNotInitialized
+	}
+	root := p.Root()
+	out := &Snapshot{
+		Version:  SnapshotVersion,
+		PubKey:   root.Pub.Hex(),
+		RootSeq:  root.Seq,
+		RootHash: root.Hash.Hex(),
+		Headers:  h.snapshot(),
+	}
+	changes := h.GetChanges()
+	out.Total, out.LastSubmission = changes.Total, changes.Last
+	out.Indexer, out.Container = v.snapshot()
+	return out, nil
+}
+
+// LoadSnapshot loads a snapshot from file.
+func LoadSnapshot(path string) (*Snapshot, error) {
+	out := new(Snapshot)
+	if e := file.LoadJSON(path, out); e != nil {
+		if os.IsNotExist(e) {
+			return nil, boo.WrapType(e, boo.NotFound, "snapshot not found")
+		}
+		return nil, boo.WrapType(e, boo.InvalidRead, "failed to read snapshot")
+	}
+	return out, nil
+}
+
+// Save saves the snapshot to file.
+func (s *Snapshot) Save(path string) error {
+	if e := file.SaveJSON(path, s, os.FileMode(0600)); e != nil {
+		return boo.WrapType(e, boo.Internal, "failed to save snapshot")
+	}
+	return nil
+}
+
+// Restore generates headers and views for the given pack from the snapshot.
+// Content submitted after the snapshot's root sequence is applied to the views.
+// An error is returned if the snapshot does not represent an ancestor of the pack's root.
+func (s *Snapshot) Restore(p *skyobject.Pack) (*Headers, *Viewer, error) {
+	root := p.Root()
+	switch {
+	case s.Version != SnapshotVersion:
+		return nil, nil, boo.Newf(boo.InvalidRead,
+			"snapshot version %d is not supported", s.Version)
+	case s.PubKey != root.Pub.Hex():
+		return nil, nil, boo.New(boo.InvalidRead,
+			"snapshot is of a different board")
+	case s.RootSeq > root.Seq:
+		return nil, nil, boo.Newf(boo.InvalidRead,
+			"snapshot seq(%d) is ahead of root seq(%d)", s.RootSeq, root.Seq)
+	case s.RootSeq == root.Seq && s.RootHash != root.Hash.Hex():
+		return nil, nil, boo.Newf(boo.InvalidRead,
+			"snapshot of seq(%d) does not match root", s.RootSeq)
+	case s.Indexer == nil || s.Container == nil || s.Headers == nil:
+		return nil, nil, boo.New(boo.InvalidRead,
+			"snapshot is incomplete")
+	}
+
+	v, e := s.restoreViewer(root.Pub)
+	if e != nil {
+		return nil, nil, e
+	}
+	v.verify = p.Flags()&skyobject.ViewOnly > 0
+	h, e := s.restoreHeaders()
+	if e != nil {
+		return nil, nil, e
+	}
+	if s.RootSeq == root.Seq {
+		return h, v, nil
+	}
+
+	// Apply delta.
+	// Changes will need reset if the submissions we have compiled are no longer those of the root.
+	if h, e = NewHeaders(h, p); e != nil {
+		return nil, nil, e
+	}
+	if h.GetChanges().NeedReset {
+		return nil, nil, boo.New(boo.InvalidRead,
+			"submissions of root have diverged from snapshot")
+	}
+	if e := v.Update(p, h); e != nil {
+		return nil, nil, e
+	}
+	return h, v, nil
+}
+
+func (s *Snapshot) restoreHeaders() (*Headers, error) {
+	h := &Headers{
+		rootSeq: s.RootSeq,
+		changes: &object.Changes{Total: s.Total, Last: s.LastSubmission},
+		threads: make(map[string]cipher.SHA256, len(s.Headers.Threads)),
+		users:   make(map[string]cipher.SHA256, len(s.Headers.Users)),
+	}
+	var e error
+	for tHash, tpHash := range s.Headers.Threads {
+		if h.threads[tHash], e = cipher.SHA256FromHex(tpHash); e != nil {
+			return nil, boo.WrapType(e, boo.InvalidRead, "invalid thread page hash in snapshot")
+		}
+	}
+	for upk, uapHash := range s.Headers.Users {
+		if h.users[upk], e = cipher.SHA256FromHex(uapHash); e != nil {
+			return nil, boo.WrapType(e, boo.InvalidRead, "invalid user profile hash in snapshot")
+		}
+	}
+	return h, nil
+}
+
+func (s *Snapshot) restoreViewer(pk cipher.PubKey) (*Viewer, error) {
+	v := &Viewer{
+		l:  inform.NewLogger(true, os.Stdout, "STATE_VIEWER"),
+		pk: pk,
+		i:  NewIndexer(),
+		c:  NewContainer(),
+		s:  NewSearchIndex(),
+	}
+
+	// Container.
+	for hash, cs := range s.Container.Content {
+		if cs == nil || cs.Header == nil || cs.Body == nil {
+			return nil, boo.Newf(boo.InvalidRead,
+				"content '%s' of snapshot is incomplete", hash)
+		}
+		v.c.content[hash] = &object.ContentRep{
+			Header: cs.Header,
+			Body:   cs.Body,
+		}
+		switch cs.Body.Type {
+		case object.V5ThreadType, object.V5PostType:
+			v.s.Add(hash, cs.Body)
+		}
+	}
+	for hash, votes := range s.Container.Votes {
+		if votes.Votes == nil {
+			votes.Votes = make(map[string]*object.Content)
+		}
+		v.c.votes[hash] = votes
+	}
+	for upk, profile := range s.Container.Profiles {
+		v.c.profiles[upk] = profile
+	}
+	for hash, qc := range s.Container.Quarantine {
+		v.c.quarantine[hash] = qc
+	}
+
+	// Indexer.
+	v.i.Board = s.Indexer.Board
+	if rep, ok := v.c.content[v.i.Board]; ok {
+		rep.PubKey = pk.Hex()
+	} else {
+		return nil, boo.New(boo.InvalidRead, "board of snapshot not found")
+	}
+	for _, tHash := range s.Indexer.Threads {
+		v.i.Threads.Append(tHash)
+	}
+	for tHash, rank := range s.Indexer.ThreadRanks {
+		v.i.ThreadRanks[tHash] = rank
+	}
+	for _, sorted := range v.i.SortedThreads {
+		for _, tHash := range s.Indexer.Threads {
+			sorted.Append(tHash)
+		}
+	}
+	for hash, pHashes := range s.Indexer.PostsOfThread {
+		posts := paginatedtypes.NewMapped()
+		for _, pHash := range pHashes {
+			posts.Append(pHash)
+		}
+		v.i.PostsOfThread[hash] = posts
+	}
+	for _, upk := range s.Indexer.Users {
+		v.i.Users.Append(upk)
+	}
+	return v, nil
+}
+
+/*
+	<<< SNAPSHOT GENERATION >>>
+*/
+
+func (h *Headers) snapshot() *HeadersSnapshot {
+	out := &HeadersSnapshot{
+		Threads: make(map[string]string),
+		Users:   make(map[string]string),
+	}
+	h.tMux.Lock()
+	for tHash, tpHash := range h.threads {
+		out.Threads[tHash] = tpHash.Hex()
+	}
+	h.tMux.Unlock()
+
+	h.uMux.Lock()
+	for upk, uapHash := range h.users {
+		out.Users[upk] = uapHash.Hex()
+	}
+	h.uMux.Unlock()
+	return out
+}
+
+// snapshot copies the views of the viewer, as they are encoded after the lock is released.
+func (v *Viewer) snapshot() (*IndexerSnapshot, *ContainerSnapshot) {
+	defer v.rLock()()
+
+	is := &IndexerSnapshot{
+		Board:         v.i.Board,
+		Threads:       paginatedList(v.i.Threads),
+		ThreadRanks:   make(map[string]*ThreadRank, len(v.i.ThreadRanks)),
+		PostsOfThread: make(map[string][]string, len(v.i.PostsOfThread)),
+		Users:         paginatedList(v.i.Users),
+	}
+	for hash, rank := range v.i.ThreadRanks {
+		rankCopy := *rank
+		is.ThreadRanks[hash] = &rankCopy
+	}
+	for hash, posts := range v.i.PostsOfThread {
+		is.PostsOfThread[hash] = paginatedList(posts)
+	}
+
+	cs := &ContainerSnapshot{
+		Content:    make(map[string]*ContentSnapshot, len(v.c.content)),
+		Votes:      make(map[string]*VotesRep, len(v.c.votes)),
+		Profiles:   make(map[string]*Profile, len(v.c.profiles)),
+		Quarantine: make(map[string]*QuarantinedContent, len(v.c.quarantine)),
+	}
+	for hash, rep := range v.c.content {
+		body, _ := rep.Body.(*object.Body)
+		cs.Content[hash] = &ContentSnapshot{
+			Header: rep.Header,
+			Body:   body,
+		}
+	}
+	for ref, votes := range v.c.votes {
+		cs.Votes[ref] = copyVotes(votes)
+	}
+	for upk, profile := range v.c.profiles {
+		cs.Profiles[upk] = copyProfile(profile)
+	}
+	for hash, q := range v.c.quarantine {
+		qCopy := *q
+		cs.Quarantine[hash] = &qCopy
+	}
+	return is, cs
+}
+
+// Content of votes is not modified once compiled, hence is not copied.
+func copyVotes(r *VotesRep) *VotesRep {
+	out := *r
+	out.Votes = make(map[string]*object.Content, len(r.Votes))
+	for upk, c := range r.Votes {
+		out.Votes[upk] = c
+	}
+	return &out
+}
+
+func copyProfile(p *Profile) *Profile {
+	return &Profile{
+		Trusted:        copySet(p.Trusted),
+		MarkedAsSpam:   copySet(p.MarkedAsSpam),
+		Blocked:        copySet(p.Blocked),
+		TrustedBy:      copySet(p.TrustedBy),
+		MarkedAsSpamBy: copySet(p.MarkedAsSpamBy),
+		BlockedBy:      copySet(p.BlockedBy),
+	}
+}
+
+func copySet(set map[string]struct{}) map[string]struct{} {
+	out := make(map[string]struct{}, len(set))
+	for k := range set {
+		out[k] = struct{}{}
+	}
+	return out
+}
+
+func paginatedList(p typ.Paginated) []string {
+	out, e := p.Get(&typ.PaginatedInput{
+		StartIndex: 0,
+		PageSize:   math.MaxUint64,
+	})
+	if e != nil {
+		return nil
+	}
+	return out.Data
+}
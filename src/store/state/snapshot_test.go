@@ -0,0 +1,191 @@
+package state
+
+import (
+	"github.com/skycoin/bbs/src/misc/typ"
+	"github.com/skycoin/bbs/src/store/object"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func obtainThreadHashes(t *testing.T, v *Viewer) []string {
+	out, e := v.GetBoardPage(&BoardPageIn{
+		PaginatedInput: typ.PaginatedInput{PageSize: math.MaxUint64},
+	})
+	if e != nil {
+		t.Fatal("failed to get board page:", e)
+	}
+	hashes := make([]string, len(out.Threads))
+	for i, thread := range out.Threads {
+		hashes[i] = thread.Header.Hash
+	}
+	return hashes
+}
+
+func TestSnapshot_Restore(t *testing.T) {
+	const (
+		boardSeed = "a"
+		userSeed  = "b"
+	)
+
+	dir, e := ioutil.TempDir("", "bbs_snapshot")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "board"+SnapshotFileExt)
+
+	bi, quit := initInstance(t, boardSeed)
+	defer quit()
+
+	t0, _ := addThread(t, bi, 0, []byte(userSeed))
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+	addPost(t, bi, t0, 0, []byte(userSeed))
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+
+	snap, e := NewSnapshot(bi.p, bi.h, bi.v)
+	if e != nil {
+		t.Fatal("failed to generate snapshot:", e)
+	}
+	if e := snap.Save(path); e != nil {
+		t.Fatal(e)
+	}
+
+	t.Run("same_seq", func(t *testing.T) {
+		snap, e := LoadSnapshot(path)
+		if e != nil {
+			t.Fatal(e)
+		}
+		_, v, e := snap.Restore(bi.p)
+		if e != nil {
+			t.Fatal("failed to restore:", e)
+		}
+		got, expected := obtainThreadHashes(t, v), obtainThreadHashes(t, bi.v)
+		if len(got) != len(expected) || got[0] != expected[0] {
+			t.Errorf("got threads %v, expected %v", got, expected)
+		}
+		if !v.HasContent(t0.Hex()) {
+			t.Error("expected restored viewer to have thread content")
+		}
+	})
+
+	t1, _ := addThread(t, bi, 1, []byte(userSeed))
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+
+	t.Run("apply_delta", func(t *testing.T) {
+		snap, e := LoadSnapshot(path)
+		if e != nil {
+			t.Fatal(e)
+		}
+		_, v, e := snap.Restore(bi.p)
+		if e != nil {
+			t.Fatal("failed to restore:", e)
+		}
+		got := obtainThreadHashes(t, v)
+		if len(got) != 2 || got[0] != t0.Hex() || got[1] != t1.Hex() {
+			t.Errorf("got threads %v, expected [%s %s]", got, t0.Hex(), t1.Hex())
+		}
+	})
+
+	t.Run("diverged", func(t *testing.T) {
+		snap, e := LoadSnapshot(path)
+		if e != nil {
+			t.Fatal(e)
+		}
+		snap.LastSubmission = t1.Hex()
+		if _, _, e := snap.Restore(bi.p); e == nil {
+			t.Error("expected error when restoring diverged snapshot")
+		}
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		if _, e := LoadSnapshot(filepath.Join(dir, "none")); e == nil {
+			t.Error("expected error when loading missing snapshot")
+		}
+	})
+}
+
+func TestBoardInstance_SnapshotInterval(t *testing.T) {
+	const (
+		boardSeed = "a"
+		userSeed  = "b"
+	)
+
+	dir, e := ioutil.TempDir("", "bbs_snapshot")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "board"+SnapshotFileExt)
+
+	bi, quit := initInstance(t, boardSeed)
+	defer quit()
+	bi.EnableSnapshot(path).SetSnapshotInterval(time.Nanosecond)
+
+	addThread(t, bi, 0, []byte(userSeed))
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+
+	snap, e := LoadSnapshot(path)
+	if e != nil {
+		t.Fatal("expected snapshot to be saved after publishing:", e)
+	}
+	if seq := bi.p.Root().Seq; snap.RootSeq != seq {
+		t.Errorf("expected snapshot of seq(%d), got seq(%d)", seq, snap.RootSeq)
+	}
+
+	t.Run("not_due", func(t *testing.T) {
+		bi.SetSnapshotInterval(time.Hour)
+		addThread(t, bi, 1, []byte(userSeed))
+		if e := bi.PublishChanges(); e != nil {
+			t.Fatal("failed to publish changes:", e)
+		}
+		snap2, e := LoadSnapshot(path)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if snap2.RootSeq != snap.RootSeq {
+			t.Errorf("expected snapshot to be kept at seq(%d), got seq(%d)", snap.RootSeq, snap2.RootSeq)
+		}
+	})
+}
+
+func TestViewer_snapshot(t *testing.T) {
+	v := &Viewer{i: NewIndexer(), c: NewContainer()}
+	v.i.ThreadRanks["t"] = &ThreadRank{Score: 1}
+	v.c.GetProfile("a").Trusted["b"] = struct{}{}
+	v.c.votes["t"] = &VotesRep{Ref: "t", Votes: make(map[string]*object.Content)}
+	v.c.quarantine["q"] = &QuarantinedContent{Hash: "q", Reason: "bad"}
+
+	is, cs := v.snapshot()
+
+	// Changes after the snapshot is taken should not be reflected.
+	v.i.ThreadRanks["t"].Score++
+	v.c.GetProfile("a").Trusted["c"] = struct{}{}
+	v.c.votes["t"].Votes["a"] = new(object.Content)
+	v.c.votes["t"].UpCount++
+	v.c.quarantine["q"].Reason = "worse"
+
+	if is.ThreadRanks["t"].Score != 1 {
+		t.Error("expected thread ranks to be copied")
+	}
+	if len(cs.Profiles["a"].Trusted) != 1 {
+		t.Error("expected profiles to be copied")
+	}
+	if len(cs.Votes["t"].Votes) != 0 || cs.Votes["t"].UpCount != 0 {
+		t.Error("expected votes to be copied")
+	}
+	if cs.Quarantine["q"].Reason != "bad" {
+		t.Error("expected quarantine to be copied")
+	}
+}
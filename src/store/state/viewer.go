@@ -11,6 +11,7 @@ import (
 	"log"
 	"math"
 	"os"
+	"sort"
 	"sync"
 )
 
@@ -25,17 +26,22 @@ var ErrViewerNotInitialized = boo.New(boo.NotFound, "viewer is not initialized")
 type Indexer struct {
 	Board         string
 	Threads       typ.Paginated
-	PostsOfThread map[string]typ.Paginated // key (hash of thread or post), value (list of posts)
+	SortedThreads map[SortMode]*paginatedtypes.Sorted // key (sort mode), value (sorted list of threads)
+	ThreadRanks   map[string]*ThreadRank              // key (hash of thread), value (values used for sorting)
+	PostsOfThread map[string]typ.Paginated            // key (hash of thread or post), value (list of posts)
 	Users         typ.Paginated
 }
 
 // NewIndexer creates a new Indexer.
 func NewIndexer() *Indexer {
-	return &Indexer{
+	i := &Indexer{
 		Threads:       paginatedtypes.NewSimple(),
+		ThreadRanks:   make(map[string]*ThreadRank),
 		PostsOfThread: make(map[string]typ.Paginated),
 		Users:         paginatedtypes.NewMapped(),
 	}
+	i.SortedThreads = i.threadSorters()
+	return i
 }
 
 // EnsureUsersOfUserVoteBody ensures that user participants of a given vote body,
@@ -50,18 +56,22 @@ func (i *Indexer) EnsureUsersOfUserVoteBody(body *object.Body) {
 */
 
 // Container contains the objects the the Indexer indexes.
+// Stored content representations are shared between responses, and hence are
+// never modified once stored (use 'Viewer.view' to obtain a per-response copy).
 type Container struct {
-	content  map[string]*object.ContentRep
-	votes    map[string]*VotesRep
-	profiles map[string]*Profile
+	content    map[string]*object.ContentRep
+	votes      map[string]*VotesRep
+	profiles   map[string]*Profile
+	quarantine map[string]*QuarantinedContent // Received content that failed verification.
 }
 
 // NewContainer creates a new Container.
 func NewContainer() *Container {
 	return &Container{
-		content:  make(map[string]*object.ContentRep),
-		votes:    make(map[string]*VotesRep),
-		profiles: make(map[string]*Profile),
+		content:    make(map[string]*object.ContentRep),
+		votes:      make(map[string]*VotesRep),
+		profiles:   make(map[string]*Profile),
+		quarantine: make(map[string]*QuarantinedContent),
 	}
 }
 
@@ -82,22 +92,31 @@ func (c *Container) GetProfile(upk string) *Profile {
 */
 
 // Viewer generates and compiles views for the board.
+// Views are compiled under a write lock, while responses are obtained under a
+// read lock so that many requests can be served in parallel.
 type Viewer struct {
-	mux sync.Mutex
-	l   *log.Logger
-	pk  cipher.PubKey
-	i   *Indexer
-	c   *Container
+	mux    sync.RWMutex
+	l      *log.Logger
+	pk     cipher.PubKey
+	i      *Indexer
+	c      *Container
+	s      *SearchIndex
+	repMux sync.Mutex         // Protects 'rep' as it is filled under read lock.
+	rep    map[string]float64 // Cached reputation seeded by board owner (nil if outdated).
+	verify bool               // Whether to verify content before compiling (for remote boards).
 }
 
 // NewViewer creates a new viewer with a given pack.
 func NewViewer(pack *skyobject.Pack) (*Viewer, error) {
 	v := &Viewer{
-		l:  inform.NewLogger(true, os.Stdout, "STATE_VIEWER"),
-		pk: pack.Root().Pub,
-		i:  NewIndexer(),
-		c:  NewContainer(),
+		l:      inform.NewLogger(true, os.Stdout, "STATE_VIEWER"),
+		pk:     pack.Root().Pub,
+		i:      NewIndexer(),
+		c:      NewContainer(),
+		s:      NewSearchIndex(),
+		verify: pack.Flags()&skyobject.ViewOnly > 0,
 	}
+	seq := pack.Root().Seq
 
 	pages, e := object.GetPages(pack, &object.GetPagesIn{
 		RootPage:  false,
@@ -122,13 +141,19 @@ func NewViewer(pack *skyobject.Pack) (*Viewer, error) {
 			return e
 		}
 		tBody, tHeader := thread.GetBody(), thread.GetHeader()
-		v.ensureUser(tBody.Creator)
-		tHash, e := v.addThread(thread, tBody, tHeader)
-		if e != nil {
-			return e
+		tAdmitted := v.admit(seq, thread, tBody, tHeader)
+		var tHash cipher.SHA256
+		if tAdmitted {
+			v.ensureUser(tBody.Creator)
+			if tHash, e = v.addThread(thread, tBody, tHeader); e != nil {
+				return e
+			}
 		}
 		return tp.RangePosts(func(i int, post *object.Content) error {
 			pBody, pHeader := post.GetBody(), post.GetHeader()
+			if !v.admit(seq, post, pBody, pHeader) || !tAdmitted {
+				return nil
+			}
 			v.ensureUser(pBody.Creator)
 			return v.addPost(tHash, post, pBody, pHeader)
 		})
@@ -140,6 +165,17 @@ func NewViewer(pack *skyobject.Pack) (*Viewer, error) {
 	e = pages.UsersPage.RangeUserProfiles(func(i int, uap *object.UserProfile) error {
 		return uap.RangeSubmissions(func(i int, c *object.Content) error {
 			vBody, vHeader := c.GetBody(), c.GetHeader()
+			switch vBody.Type {
+			case object.V5ThreadType, object.V5PostType:
+				// Already verified when ranging thread pages.
+				if _, ok := v.c.quarantine[vHeader.Hash]; ok {
+					return nil
+				}
+			default:
+				if !v.admit(seq, c, vBody, vHeader) {
+					return nil
+				}
+			}
 			v.ensureUser(vBody.Creator)
 			if e := v.processVote(c, vBody, vHeader); e != nil {
 				return e
@@ -161,6 +197,12 @@ func (v *Viewer) Update(pack *skyobject.Pack, headers *Headers) error {
 	}
 	defer v.lock()()
 
+	// Submissions were removed or altered, so views need to be recompiled.
+	if headers.GetChanges().NeedReset {
+		v.l.Println("submissions of board have been reset, recompiling views.")
+		return v.reset(pack)
+	}
+
 	pages, e := object.GetPages(pack, &object.GetPagesIn{
 		RootPage:  false,
 		BoardPage: true,
@@ -183,6 +225,9 @@ func (v *Viewer) Update(pack *skyobject.Pack, headers *Headers) error {
 			body   = content.GetBody()
 		)
 
+		if !v.admit(pack.Root().Seq, content, body, header) {
+			continue
+		}
 		v.ensureUser(body.Creator)
 
 		switch body.Type {
@@ -203,11 +248,50 @@ func (v *Viewer) Update(pack *skyobject.Pack, headers *Headers) error {
 	return nil
 }
 
+// Reset recompiles all views from the pack.
+func (v *Viewer) Reset(pack *skyobject.Pack) error {
+	if v == nil {
+		return ErrViewerNotInitialized
+	}
+	defer v.lock()()
+	return v.reset(pack)
+}
+
+// reset recompiles all views from the pack.
+// Should only be used when viewer is locked.
+func (v *Viewer) reset(pack *skyobject.Pack) error {
+	fresh, e := NewViewer(pack)
+	if e != nil {
+		return e
+	}
+	v.i, v.c, v.s, v.rep = fresh.i, fresh.c, fresh.s, nil
+	return nil
+}
+
 func (v *Viewer) lock() func() {
 	v.mux.Lock()
 	return v.mux.Unlock
 }
 
+func (v *Viewer) rLock() func() {
+	v.mux.RLock()
+	return v.mux.RUnlock
+}
+
+// view obtains a copy of stored content with votes as seen from perspective.
+// Should only be used when viewer is locked.
+func (v *Viewer) view(hash string, rep *object.ContentRep, perspective string) *object.ContentRep {
+	out := *rep
+	if votes, ok := v.c.votes[hash]; ok {
+		out.Votes = votes.View(perspective)
+	}
+	return &out
+}
+
+// setBoard sets the board content. Unlike submissions, the board content is not
+// verified against it's header signature: it is only modified by the board's owner
+// (whose signature of the root covers it), and edits such as of submission keys are
+// made without re-signing the header.
 func (v *Viewer) setBoard(bc *object.Content) {
 	delete(v.c.content, v.i.Board)
 	v.i.Board = bc.GetHeader().Hash
@@ -219,13 +303,16 @@ func (v *Viewer) setBoard(bc *object.Content) {
 func (v *Viewer) addThread(tc *object.Content, b *object.Body, h *object.ContentHeaderData) (cipher.SHA256, error) {
 
 	// Check board public key.
-	if e := checkBoardRef(v.pk, b, "thread"); e != nil {
+	if e := v.checkBoardRef(b, "thread"); e != nil {
 		return cipher.SHA256{}, e
 	}
 
 	tHash := h.GetHash()
 	v.i.Threads.Append(tHash.Hex())
+	v.i.AddThreadRank(tHash.Hex(), b.TS)
 	v.c.content[tHash.Hex()] = tc.ToRep()
+	v.s.Add(tHash.Hex(), b)
+	v.rep = nil
 	v.i.PostsOfThread[tHash.Hex()] = paginatedtypes.NewMapped()
 	return tHash, nil
 }
@@ -233,7 +320,7 @@ func (v *Viewer) addThread(tc *object.Content, b *object.Body, h *object.Content
 func (v *Viewer) addPost(tHash cipher.SHA256, pc *object.Content, b *object.Body, h *object.ContentHeaderData) error {
 
 	// Check board public key.
-	if e := checkBoardRef(v.pk, b, "post"); e != nil {
+	if e := v.checkBoardRef(b, "post"); e != nil {
 		return e
 	}
 
@@ -248,6 +335,9 @@ func (v *Viewer) addPost(tHash cipher.SHA256, pc *object.Content, b *object.Body
 	} else {
 		posts.Append(pHash)
 		v.c.content[pHash] = pc.ToRep()
+		v.s.Add(pHash, b)
+		v.rep = nil
+		v.i.BumpThread(tHash.Hex(), b.TS)
 	}
 
 	if ofPost, _ := b.GetOfPost(); ofPost != (cipher.SHA256{}) {
@@ -266,10 +356,13 @@ func (v *Viewer) ensureUser(upk string) {
 	v.i.Users.Append(upk)
 	if _, ok := v.c.profiles[upk]; !ok {
 		v.c.profiles[upk] = NewProfile()
+		v.rep = nil
 	}
 }
 
 func (v *Viewer) processVote(c *object.Content, b *object.Body, h *object.ContentHeaderData) error {
+	v.rep = nil
+
 	var cHash string
 	var cType object.ContentType
 
@@ -302,6 +395,10 @@ func (v *Viewer) processVote(c *object.Content, b *object.Body, h *object.Conten
 	}
 	voteRep.Add(c)
 
+	if cType == object.V5ThreadVoteType {
+		v.i.ScoreThread(cHash, voteRep.UpCount-voteRep.DownCount)
+	}
+
 	return nil
 }
 
@@ -314,6 +411,14 @@ func (v *Viewer) processUserVote(c *object.Content, b *object.Body, h *object.Co
 	creatorProfile.ClearVotesFor(b.OfUser)
 	ofUserProfile.ClearVotesBy(b.Creator)
 
+	// Keep signed votes of user for auditing.
+	voteRep, has := v.c.votes[b.OfUser]
+	if !has {
+		voteRep = new(VotesRep).Fill(object.V5UserVoteType, b.OfUser)
+		v.c.votes[b.OfUser] = voteRep
+	}
+	voteRep.Add(c)
+
 	switch b.Value {
 	case +1:
 		if b.HasTag(object.TrustTag) {
@@ -346,7 +451,7 @@ func (v *Viewer) HasUser(upk string) bool {
 	if v == nil {
 		return false
 	}
-	defer v.lock()()
+	defer v.rLock()()
 	return v.i.Users.Has(upk)
 }
 
@@ -354,15 +459,30 @@ func (v *Viewer) HasThread(tHash string) bool {
 	if v == nil {
 		return false
 	}
-	defer v.lock()()
+	defer v.rLock()()
 	return v.i.Threads.Has(tHash)
 }
 
+// GetThreadOfPost obtains the hash of the thread in which the post resides.
+// Returns an empty string if the post is not found.
+func (v *Viewer) GetThreadOfPost(pHash string) string {
+	if v == nil {
+		return ""
+	}
+	defer v.rLock()()
+	if rep, ok := v.c.content[pHash]; ok {
+		if body, ok := rep.Body.(*object.Body); ok && body.Type == object.V5PostType {
+			return body.OfThread
+		}
+	}
+	return ""
+}
+
 func (v *Viewer) HasContent(hash string) bool {
 	if v == nil {
 		return false
 	}
-	defer v.lock()()
+	defer v.rLock()()
 	_, ok := v.c.content[hash]
 	return ok
 }
@@ -376,13 +496,15 @@ func (v *Viewer) GetBoard() (*object.ContentRep, error) {
 	if v == nil {
 		return nil, ErrViewerNotInitialized
 	}
-	defer v.lock()()
+	defer v.rLock()()
 	return v.c.content[v.i.Board], nil
 }
 
 // BoardPageIn represents the input required to obtain board page.
 type BoardPageIn struct {
 	Perspective    string
+	SortMode       SortMode
+	Filter         FilterIn
 	PaginatedInput typ.PaginatedInput
 }
 
@@ -394,13 +516,23 @@ type BoardPageOut struct {
 }
 
 // GetBoardPage obtains a board page.
-func (v *Viewer) GetBoardPage(in *BoardPageIn) (*BoardPageOut, error) {
+func (v *Viewer) GetBoardPage(in *BoardPageIn) (_ *BoardPageOut, e error) {
 	if v == nil {
 		return nil, ErrViewerNotInitialized
 	}
-	defer v.lock()()
+	defer v.rLock()()
 
-	tHashes, e := v.i.Threads.Get(&in.PaginatedInput)
+	var threads typ.Paginated
+	if in.SortMode == SortReputation {
+		threads = v.threadsByReputation(in.Perspective)
+	} else if threads, e = v.i.GetThreads(in.SortMode); e != nil {
+		return nil, e
+	}
+	tHashes, e := threads.Get(&in.PaginatedInput)
+	if e != nil {
+		return nil, e
+	}
+	filter, e := v.newContentFilter(in.Perspective, &in.Filter)
 	if e != nil {
 		return nil, e
 	}
@@ -408,12 +540,13 @@ func (v *Viewer) GetBoardPage(in *BoardPageIn) (*BoardPageOut, error) {
 	out := new(BoardPageOut)
 	out.Board = v.c.content[v.i.Board]
 	//out.ThreadsMeta = tHashes
-	out.Threads = make([]*object.ContentRep, len(tHashes.Data))
-	for i, tHash := range tHashes.Data {
-		out.Threads[i] = v.c.content[tHash]
-		if votes, ok := v.c.votes[tHash]; ok {
-			out.Threads[i].Votes = votes.View(in.Perspective)
+	out.Threads = make([]*object.ContentRep, 0, len(tHashes.Data))
+	for _, tHash := range tHashes.Data {
+		thread, ok := filter.apply(v.c.content[tHash])
+		if !ok || thread == nil {
+			continue
 		}
+		out.Threads = append(out.Threads, v.view(tHash, thread, in.Perspective))
 	}
 	return out, nil
 }
@@ -422,6 +555,7 @@ func (v *Viewer) GetBoardPage(in *BoardPageIn) (*BoardPageOut, error) {
 type ThreadPageIn struct {
 	Perspective    string
 	ThreadHash     string
+	Filter         FilterIn
 	PaginatedInput typ.PaginatedInput
 }
 
@@ -438,29 +572,35 @@ func (v *Viewer) GetThreadPage(in *ThreadPageIn) (*ThreadPageOut, error) {
 	if v == nil {
 		return nil, ErrViewerNotInitialized
 	}
-	defer v.lock()()
+	defer v.rLock()()
+	filter, e := v.newContentFilter(in.Perspective, &in.Filter)
+	if e != nil {
+		return nil, e
+	}
 	out := new(ThreadPageOut)
 	out.Board = v.c.content[v.i.Board]
-	out.Thread = v.c.content[in.ThreadHash]
 
-	if out.Thread == nil {
+	if thread := v.c.content[in.ThreadHash]; thread == nil {
 		return nil, boo.Newf(boo.NotFound, "thread of hash '%s' is not found in board '%s'",
 			in.ThreadHash, v.pk.Hex())
-	}
-	if votes, ok := v.c.votes[in.ThreadHash]; ok {
-		out.Thread.Votes = votes.View(in.Perspective)
+	} else if thread, _ = filter.apply(thread); thread == nil {
+		return nil, boo.Newf(boo.NotFound, "thread of hash '%s' is filtered from perspective",
+			in.ThreadHash)
+	} else {
+		out.Thread = v.view(in.ThreadHash, thread, in.Perspective)
 	}
 
 	pHashes, e := v.i.PostsOfThread[in.ThreadHash].Get(&in.PaginatedInput)
 	if e != nil {
 		return nil, e
 	}
-	out.Posts = make([]*object.ContentRep, len(pHashes.Data))
-	for i, pHash := range pHashes.Data {
-		out.Posts[i] = v.c.content[pHash]
-		if votes, ok := v.c.votes[pHash]; ok {
-			out.Posts[i].Votes = votes.View(in.Perspective)
+	out.Posts = make([]*object.ContentRep, 0, len(pHashes.Data))
+	for _, pHash := range pHashes.Data {
+		post, ok := filter.apply(v.c.content[pHash])
+		if !ok || post == nil {
+			continue
 		}
+		out.Posts = append(out.Posts, v.view(pHash, post, in.Perspective))
 	}
 
 	return out, nil
@@ -482,7 +622,7 @@ func (v *Viewer) GetVotes(in *ContentVotesIn) (*ContentVotesOut, error) {
 	if v == nil {
 		return nil, ErrViewerNotInitialized
 	}
-	defer v.lock()()
+	defer v.rLock()()
 	out := new(ContentVotesOut)
 	if votes, ok := v.c.votes[in.ContentHash]; ok {
 		out.Votes = votes.View(in.Perspective)
@@ -499,11 +639,13 @@ func (v *Viewer) GetVotes(in *ContentVotesIn) (*ContentVotesOut, error) {
 }
 
 type UserProfileIn struct {
-	UserPubKey string
+	Perspective string
+	UserPubKey  string
 }
 
 type UserProfileOut struct {
 	UserPubKey string       `json:"user_public_key"`
+	Reputation float64      `json:"reputation"`
 	Profile    *ProfileView `json:"profile"`
 }
 
@@ -511,7 +653,7 @@ func (v *Viewer) GetUserProfile(in *UserProfileIn) (*UserProfileOut, error) {
 	if v == nil {
 		return nil, ErrViewerNotInitialized
 	}
-	defer v.lock()()
+	defer v.rLock()()
 	if !v.i.Users.Has(in.UserPubKey) {
 		return nil, boo.Newf(boo.NotFound,
 			"user of public key %s is not found", in.UserPubKey)
@@ -523,19 +665,27 @@ func (v *Viewer) GetUserProfile(in *UserProfileIn) (*UserProfileOut, error) {
 	}
 	return &UserProfileOut{
 		UserPubKey: in.UserPubKey,
+		Reputation: v.reputation(in.Perspective)[in.UserPubKey],
 		Profile:    profile.View(),
 	}, nil
 }
 
+type ParticipantsIn struct {
+	Perspective      string
+	SortByReputation bool
+	MinReputation    *float64
+}
+
 type ParticipantsOut struct {
-	Participants []string `json:"participants"`
+	Participants []string           `json:"participants"`
+	Reputations  map[string]float64 `json:"reputations"`
 }
 
-func (v *Viewer) GetParticipants() (*ParticipantsOut, error) {
+func (v *Viewer) GetParticipants(in *ParticipantsIn) (*ParticipantsOut, error) {
 	if v == nil {
 		return nil, ErrViewerNotInitialized
 	}
-	defer v.lock()()
+	defer v.rLock()()
 	out, e := v.i.Users.Get(&typ.PaginatedInput{
 		StartIndex: 0,
 		PageSize:   math.MaxUint64,
@@ -543,11 +693,63 @@ func (v *Viewer) GetParticipants() (*ParticipantsOut, error) {
 	if e != nil {
 		return nil, e
 	}
+	var (
+		reputation = v.reputation(in.Perspective)
+		upks       = make([]string, 0, len(out.Data))
+		reps       = make(map[string]float64, len(out.Data))
+	)
+	for _, upk := range out.Data {
+		if in.MinReputation != nil && reputation[upk] < *in.MinReputation {
+			continue
+		}
+		upks = append(upks, upk)
+		reps[upk] = reputation[upk]
+	}
+	if in.SortByReputation {
+		sort.SliceStable(upks, func(i, j int) bool {
+			return reps[upks[i]] > reps[upks[j]]
+		})
+	}
 	return &ParticipantsOut{
-		Participants: out.Data,
+		Participants: upks,
+		Reputations:  reps,
 	}, nil
 }
 
+// threadsByReputation obtains threads ordered by the reputation of their creators.
+// Should only be used when viewer is locked.
+func (v *Viewer) threadsByReputation(perspective string) typ.Paginated {
+	tHashes, _ := v.i.Threads.Get(&typ.PaginatedInput{
+		StartIndex: 0,
+		PageSize:   math.MaxUint64,
+	})
+	out := paginatedtypes.NewSimple()
+	if tHashes == nil {
+		return out
+	}
+	v.sortByReputation(tHashes.Data, v.reputation(perspective))
+	for _, tHash := range tHashes.Data {
+		out.Append(tHash)
+	}
+	return out
+}
+
+// checkBoardRef checks that content is of this board, or of a board in this board's lineage
+// (content carried over from a previous keypair or forked board).
+// Should only be used when viewer is locked.
+func (v *Viewer) checkBoardRef(body *object.Body, what string) error {
+	e := checkBoardRef(v.pk, body, what)
+	if e == nil {
+		return nil
+	}
+	if rep, ok := v.c.content[v.i.Board]; ok {
+		if board, ok := rep.Body.(*object.Body); ok && board.HasLineage(body.OfBoard) {
+			return nil
+		}
+	}
+	return e
+}
+
 /*
 	<<< HELPER FUNCTIONS >>>
 */
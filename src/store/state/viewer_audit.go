@@ -0,0 +1,94 @@
+package state
+
+import (
+	"github.com/skycoin/bbs/src/misc/boo"
+	"github.com/skycoin/bbs/src/store/object"
+	"sort"
+)
+
+// DefaultVoteAuditPageSize is the page size used for vote audits when none is specified.
+const DefaultVoteAuditPageSize = 100
+
+// VoteAuditIn represents the input required to obtain the signed votes behind a tally.
+type VoteAuditIn struct {
+	Ref        string // Hash of thread or post, or public key of user.
+	StartIndex int
+	PageSize   int
+}
+
+// SignedVote represents a vote as submitted and signed by it's creator.
+type SignedVote struct {
+	Signer  string          `json:"signer"` // Public key of creator.
+	Value   int             `json:"value"`
+	TS      int64           `json:"ts"`
+	Content *object.Content `json:"content"` // Raw header and body, verifiable with 'Content.Verify'.
+}
+
+// VoteAuditOut represents the signed votes behind a tally.
+// Only the latest vote of each signer is counted, and votes of value 0 withdraw previous votes.
+type VoteAuditOut struct {
+	Ref        string             `json:"ref"`
+	Type       object.ContentType `json:"type"`
+	UpCount    int                `json:"up_count"`
+	DownCount  int                `json:"down_count"`
+	TotalCount int                `json:"total_count"` // Number of signed votes.
+	StartIndex int                `json:"start_index"`
+	Votes      []*SignedVote      `json:"votes"` // Ordered by time of creation, then signer.
+}
+
+// GetVoteAudit obtains the signed votes in which the tally of the referenced content or user is computed from.
+func (v *Viewer) GetVoteAudit(in *VoteAuditIn) (*VoteAuditOut, error) {
+	if v == nil {
+		return nil, ErrViewerNotInitialized
+	}
+	if in.StartIndex < 0 {
+		return nil, boo.Newf(boo.InvalidInput, "invalid start index %d", in.StartIndex)
+	}
+	pageSize := in.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultVoteAuditPageSize
+	}
+	defer v.rLock()()
+
+	out := &VoteAuditOut{
+		Ref:        in.Ref,
+		StartIndex: in.StartIndex,
+		Votes:      make([]*SignedVote, 0),
+	}
+	votes, ok := v.c.votes[in.Ref]
+	if !ok {
+		if _, isContent := v.c.content[in.Ref]; !isContent && !v.i.Users.Has(in.Ref) {
+			return nil, boo.Newf(boo.NotFound, "content or user '%s' is not found", in.Ref)
+		}
+		return out, nil
+	}
+	out.Type = votes.Type
+	out.UpCount = votes.UpCount
+	out.DownCount = votes.DownCount
+
+	all := make([]*SignedVote, 0, len(votes.Votes))
+	for signer, c := range votes.Votes {
+		body := c.GetBody()
+		all = append(all, &SignedVote{
+			Signer:  signer,
+			Value:   votes.GetValue(c),
+			TS:      body.TS,
+			Content: c,
+		})
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].TS != all[j].TS {
+			return all[i].TS < all[j].TS
+		}
+		return all[i].Signer < all[j].Signer
+	})
+	out.TotalCount = len(all)
+	if in.StartIndex < len(all) {
+		end := in.StartIndex + pageSize
+		if end > len(all) {
+			end = len(all)
+		}
+		out.Votes = all[in.StartIndex:end]
+	}
+	return out, nil
+}
@@ -0,0 +1,72 @@
+package state
+
+import (
+	"testing"
+)
+
+func TestViewer_GetVoteAudit(t *testing.T) {
+	const (
+		boardSeed = "a"
+	)
+	voterSeeds := []string{"b", "c", "d"}
+
+	bi, quit := initInstance(t, boardSeed)
+	defer quit()
+
+	tHash, _ := addThread(t, bi, 0, []byte(voterSeeds[0]))
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+	addThreadVote(t, bi, tHash, +1, []byte(voterSeeds[0]))
+	addThreadVote(t, bi, tHash, +1, []byte(voterSeeds[1]))
+	addThreadVote(t, bi, tHash, -1, []byte(voterSeeds[2]))
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+
+	out, e := bi.Viewer().GetVoteAudit(&VoteAuditIn{Ref: tHash.Hex()})
+	if e != nil {
+		t.Fatal(e)
+	}
+	if out.TotalCount != 3 || len(out.Votes) != 3 {
+		t.Fatalf("expected 3 votes, got %d", len(out.Votes))
+	}
+
+	// Recompute tally from verified votes.
+	var up, down int
+	for _, vote := range out.Votes {
+		body, e := vote.Content.Verify()
+		if e != nil {
+			t.Fatal("failed to verify vote:", e)
+		}
+		if body.Creator != vote.Signer || body.OfThread != tHash.Hex() {
+			t.Errorf("unexpected vote body: %+v", body)
+		}
+		switch body.Value {
+		case +1:
+			up++
+		case -1:
+			down++
+		}
+	}
+	if up != out.UpCount || down != out.DownCount || up != 2 || down != 1 {
+		t.Errorf("recomputed tally (%d, %d) does not match (%d, %d)",
+			up, down, out.UpCount, out.DownCount)
+	}
+
+	t.Run("pagination", func(t *testing.T) {
+		page, e := bi.Viewer().GetVoteAudit(&VoteAuditIn{Ref: tHash.Hex(), StartIndex: 2, PageSize: 2})
+		if e != nil {
+			t.Fatal(e)
+		}
+		if len(page.Votes) != 1 || page.Votes[0].Signer != out.Votes[2].Signer {
+			t.Errorf("unexpected page: %+v", page.Votes)
+		}
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		if _, e := bi.Viewer().GetVoteAudit(&VoteAuditIn{Ref: "unknown"}); e == nil {
+			t.Error("expected error for unknown reference")
+		}
+	})
+}
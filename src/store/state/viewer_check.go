@@ -0,0 +1,120 @@
+package state
+
+import (
+	"github.com/skycoin/bbs/src/store/object"
+	"github.com/skycoin/cxo/skyobject"
+	"sort"
+)
+
+// ConsistencyReport represents the differences between the compiled views of
+// a board and the content of it's root.
+type ConsistencyReport struct {
+	BoardPubKey string `json:"board_public_key"`
+	RootSeq     uint64 `json:"root_seq"`
+	Consistent  bool   `json:"consistent"`
+	Repaired    bool   `json:"repaired"`
+
+	Submissions         int `json:"submissions"`          // Number of submissions in root.
+	CompiledSubmissions int `json:"compiled_submissions"` // Number of submissions compiled into views.
+
+	MissingThreads []string `json:"missing_threads"` // In root, but not in views.
+	ExtraThreads   []string `json:"extra_threads"`   // In views, but not in root.
+	MissingPosts   []string `json:"missing_posts"`   // In root, but not in views.
+	ExtraPosts     []string `json:"extra_posts"`     // In views, but not in root.
+	MissingUsers   []string `json:"missing_users"`   // Has profile in root, but not in views.
+}
+
+// check compares the compiled views against the content of the pack.
+func (v *Viewer) check(pack *skyobject.Pack, headers *Headers) (*ConsistencyReport, error) {
+	if v == nil {
+		return nil, ErrViewerNotInitialized
+	}
+	defer v.rLock()()
+
+	pages, e := object.GetPages(pack, &object.GetPagesIn{
+		RootPage:  false,
+		BoardPage: true,
+		DiffPage:  true,
+		UsersPage: true,
+	})
+	if e != nil {
+		return nil, e
+	}
+
+	out := &ConsistencyReport{
+		BoardPubKey:         v.pk.Hex(),
+		RootSeq:             pack.Root().Seq,
+		CompiledSubmissions: headers.GetChanges().Total,
+	}
+	out.Submissions, _ = pages.DiffPage.Submissions.Len()
+
+	// Compare threads and posts.
+	var (
+		packThreads = make(map[string]struct{})
+		packPosts   = make(map[string]struct{})
+	)
+	e = pages.BoardPage.RangeThreadPages(func(_ int, tp *object.ThreadPage) error {
+		thread, e := tp.GetThread()
+		if e != nil {
+			return e
+		}
+		tHash := thread.GetHeader().Hash
+		packThreads[tHash] = struct{}{}
+		if _, ok := v.c.quarantine[tHash]; !ok && !v.i.Threads.Has(tHash) {
+			out.MissingThreads = append(out.MissingThreads, tHash)
+		}
+		posts := v.i.PostsOfThread[tHash]
+		return tp.RangePosts(func(_ int, post *object.Content) error {
+			pHash := post.GetHeader().Hash
+			packPosts[pHash] = struct{}{}
+			if _, ok := v.c.quarantine[pHash]; ok {
+				return nil
+			}
+			if posts == nil || !posts.Has(pHash) {
+				out.MissingPosts = append(out.MissingPosts, pHash)
+			}
+			return nil
+		})
+	})
+	if e != nil {
+		return nil, e
+	}
+	for _, tHash := range paginatedList(v.i.Threads) {
+		if _, ok := packThreads[tHash]; !ok {
+			out.ExtraThreads = append(out.ExtraThreads, tHash)
+		}
+	}
+	for hash, rep := range v.c.content {
+		body, ok := rep.Body.(*object.Body)
+		if !ok || body.Type != object.V5PostType {
+			continue
+		}
+		if _, ok := packPosts[hash]; !ok {
+			out.ExtraPosts = append(out.ExtraPosts, hash)
+		}
+	}
+
+	// Compare users (users with only quarantined submissions are not compiled).
+	quarantined := make(map[string]struct{})
+	for _, qc := range v.c.quarantine {
+		quarantined[qc.Creator] = struct{}{}
+	}
+	e = pages.UsersPage.RangeUserProfiles(func(_ int, uap *object.UserProfile) error {
+		if _, ok := quarantined[uap.PubKey]; !ok && !v.i.Users.Has(uap.PubKey) {
+			out.MissingUsers = append(out.MissingUsers, uap.PubKey)
+		}
+		return nil
+	})
+	if e != nil {
+		return nil, e
+	}
+
+	sort.Strings(out.ExtraThreads)
+	sort.Strings(out.ExtraPosts)
+
+	out.Consistent = out.Submissions == out.CompiledSubmissions &&
+		len(out.MissingThreads) == 0 && len(out.ExtraThreads) == 0 &&
+		len(out.MissingPosts) == 0 && len(out.ExtraPosts) == 0 &&
+		len(out.MissingUsers) == 0
+	return out, nil
+}
@@ -0,0 +1,81 @@
+package state
+
+import (
+	"github.com/skycoin/bbs/src/store/object"
+	"testing"
+)
+
+func TestBoardInstance_CheckConsistency(t *testing.T) {
+	const (
+		boardSeed = "a"
+		userSeed  = "b"
+	)
+
+	bi, quit := initInstance(t, boardSeed)
+	defer quit()
+
+	tHash, _ := addThread(t, bi, 0, []byte(userSeed))
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+	addPost(t, bi, tHash, 0, []byte(userSeed))
+
+	// Unpublished changes are not checked, nor published by check.
+	out, e := bi.CheckConsistency(false)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if !out.Consistent {
+		t.Fatalf("expected views to be consistent: %+v", out)
+	}
+	if !bi.needPublish.Value() {
+		t.Error("expected check to not publish changes")
+	}
+	if _, e := bi.CheckConsistency(true); e == nil {
+		t.Error("expected repair with unpublished changes to be refused")
+	}
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+
+	corrupt := func() {
+		bi.v.c.content["extra"] = &object.ContentRep{
+			Body: &object.Body{Type: object.V5PostType},
+		}
+	}
+
+	t.Run("detect", func(t *testing.T) {
+		corrupt()
+		out, e := bi.CheckConsistency(false)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if out.Consistent || len(out.ExtraPosts) != 1 || out.Repaired {
+			t.Errorf("expected single extra post to be reported: %+v", out)
+		}
+	})
+
+	t.Run("repair", func(t *testing.T) {
+		out, e := bi.CheckConsistency(true)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if !out.Consistent || !out.Repaired {
+			t.Errorf("expected views to be repaired: %+v", out)
+		}
+	})
+
+	t.Run("update_with_reset", func(t *testing.T) {
+		corrupt()
+		headers := &Headers{changes: &object.Changes{NeedReset: true}}
+		if e := bi.v.Update(bi.p, headers); e != nil {
+			t.Fatal(e)
+		}
+		if bi.v.HasContent("extra") {
+			t.Error("expected views to be recompiled on reset")
+		}
+		if !bi.v.HasThread(tHash.Hex()) {
+			t.Error("expected recompiled views to have thread")
+		}
+	})
+}
@@ -0,0 +1,124 @@
+package state
+
+import (
+	"fmt"
+	"github.com/skycoin/bbs/src/misc/boo"
+	"github.com/skycoin/bbs/src/store/object"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultFeedPageSize is the number of feed items obtained when none is specified.
+const DefaultFeedPageSize = 30
+
+// FeedCursor determines a position in a feed.
+// Feeds are ordered by timestamp (most recent first), then by hash.
+type FeedCursor struct {
+	TS   int64
+	Hash string
+}
+
+// GetFeedCursor obtains a feed cursor from string (formatted as '<timestamp>.<hash>').
+func GetFeedCursor(v string) (*FeedCursor, error) {
+	parts := strings.SplitN(v, ".", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return nil, boo.Newf(boo.InvalidInput, "invalid feed cursor '%s'", v)
+	}
+	ts, e := strconv.ParseInt(parts[0], 10, 64)
+	if e != nil {
+		return nil, boo.WrapTypef(e, boo.InvalidInput, "invalid feed cursor '%s'", v)
+	}
+	return &FeedCursor{TS: ts, Hash: parts[1]}, nil
+}
+
+// String returns the cursor formatted as '<timestamp>.<hash>'.
+func (c FeedCursor) String() string {
+	return fmt.Sprintf("%d.%s", c.TS, c.Hash)
+}
+
+// before determines whether the cursor is positioned before (more recent than) 'o' in a feed.
+func (c FeedCursor) before(o FeedCursor) bool {
+	if c.TS != o.TS {
+		return c.TS > o.TS
+	}
+	return c.Hash > o.Hash
+}
+
+// FeedItem represents a thread or post of a feed.
+type FeedItem struct {
+	BoardPubKey string             `json:"board_public_key"`
+	ThreadHash  string             `json:"thread_hash"`
+	Content     *object.ContentRep `json:"content"`
+	cursor      FeedCursor
+}
+
+// Cursor obtains the position of the item in a feed.
+func (i *FeedItem) Cursor() FeedCursor {
+	return i.cursor
+}
+
+// SortFeedItems sorts feed items, most recent first.
+func SortFeedItems(items []*FeedItem) {
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].cursor.before(items[j].cursor)
+	})
+}
+
+// FeedIn represents the input required to obtain feed items of a board.
+type FeedIn struct {
+	Types   []object.ContentType // Thread and/or post, empty includes both.
+	Authors []string             // Public keys of creators, empty includes all.
+	After   *FeedCursor          // Items of and before this position are excluded (optional).
+	Limit   int                  // Max number of items to obtain.
+}
+
+// GetFeed obtains the most recent threads and posts of the board.
+func (v *Viewer) GetFeed(in *FeedIn) ([]*FeedItem, error) {
+	if v == nil {
+		return nil, ErrViewerNotInitialized
+	}
+	limit := in.Limit
+	if limit <= 0 {
+		limit = DefaultFeedPageSize
+	}
+	defer v.rLock()()
+
+	var out []*FeedItem
+	for hash, rep := range v.c.content {
+		body, ok := bodyOf(rep)
+		if !ok {
+			continue
+		}
+		switch body.Type {
+		case object.V5ThreadType, object.V5PostType:
+		default:
+			continue
+		}
+		if !hasContentType(in.Types, body.Type) {
+			continue
+		}
+		if len(in.Authors) > 0 && !hasString(in.Authors, body.Creator) {
+			continue
+		}
+		cursor := FeedCursor{TS: body.TS, Hash: hash}
+		if in.After != nil && !in.After.before(cursor) {
+			continue
+		}
+		item := &FeedItem{
+			BoardPubKey: v.pk.Hex(),
+			ThreadHash:  body.OfThread,
+			Content:     rep,
+			cursor:      cursor,
+		}
+		if body.Type == object.V5ThreadType {
+			item.ThreadHash = hash
+		}
+		out = append(out, item)
+	}
+	SortFeedItems(out)
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
@@ -0,0 +1,80 @@
+package state
+
+import (
+	"github.com/skycoin/bbs/src/store/object"
+	"github.com/skycoin/skycoin/src/cipher"
+	"testing"
+)
+
+func TestViewer_GetFeed(t *testing.T) {
+	const (
+		boardSeed = "a"
+		userSeedA = "b"
+		userSeedB = "c"
+	)
+
+	bi, quit := initInstance(t, boardSeed)
+	defer quit()
+
+	tHash, _ := addThread(t, bi, 0, []byte(userSeedA))
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+	addPost(t, bi, tHash, 0, []byte(userSeedA))
+	addPost(t, bi, tHash, 1, []byte(userSeedB))
+	addPost(t, bi, tHash, 2, []byte(userSeedA))
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+
+	names := func(items []*FeedItem) []string {
+		out := make([]string, len(items))
+		for i, item := range items {
+			out[i] = item.Content.Body.(*object.Body).Name
+		}
+		return out
+	}
+	check := func(t *testing.T, in *FeedIn, exp ...string) []*FeedItem {
+		items, e := bi.Viewer().GetFeed(in)
+		if e != nil {
+			t.Fatal(e)
+		}
+		got := names(items)
+		if len(got) != len(exp) {
+			t.Fatalf("expected %v, got %v", exp, got)
+		}
+		for i := range exp {
+			if got[i] != exp[i] {
+				t.Fatalf("expected %v, got %v", exp, got)
+			}
+		}
+		return items
+	}
+
+	t.Run("all", func(t *testing.T) {
+		items := check(t, &FeedIn{}, "Post 2", "Post 1", "Post 0", "Thread 0")
+		for _, item := range items {
+			if item.ThreadHash != tHash.Hex() {
+				t.Errorf("expected thread hash '%s', got '%s'", tHash.Hex(), item.ThreadHash)
+			}
+		}
+	})
+
+	t.Run("types", func(t *testing.T) {
+		check(t, &FeedIn{Types: []object.ContentType{object.V5ThreadType}}, "Thread 0")
+	})
+
+	t.Run("authors", func(t *testing.T) {
+		upk, _ := cipher.GenerateDeterministicKeyPair([]byte(userSeedB))
+		check(t, &FeedIn{Authors: []string{upk.Hex()}}, "Post 1")
+	})
+
+	t.Run("cursor", func(t *testing.T) {
+		items := check(t, &FeedIn{Limit: 2}, "Post 2", "Post 1")
+		cursor, e := GetFeedCursor(items[1].Cursor().String())
+		if e != nil {
+			t.Fatal(e)
+		}
+		check(t, &FeedIn{Limit: 2, After: cursor}, "Post 0", "Thread 0")
+	})
+}
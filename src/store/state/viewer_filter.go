@@ -0,0 +1,157 @@
+package state
+
+import (
+	"github.com/skycoin/bbs/src/misc/boo"
+	"github.com/skycoin/bbs/src/store/object"
+)
+
+// FilterMode determines how content of users that are blocked or marked as
+// spam (from the perspective of a user) is presented.
+type FilterMode string
+
+const (
+	FilterNone     = FilterMode("")         // Content is not filtered.
+	FilterHide     = FilterMode("hide")     // Content is removed from the view.
+	FilterCollapse = FilterMode("collapse") // Content is kept, but marked as collapsed.
+)
+
+// MaxTrustDepth is the max number of trust relations to follow when
+// determining what users to filter.
+const MaxTrustDepth = 5
+
+// GetFilterMode obtains a filter mode from string.
+func GetFilterMode(v string) (FilterMode, error) {
+	switch m := FilterMode(v); m {
+	case FilterNone, FilterHide, FilterCollapse:
+		return m, nil
+	}
+	return FilterNone, boo.Newf(boo.InvalidInput,
+		"invalid filter mode '%s', valid modes are: '%s', '%s'",
+		v, FilterHide, FilterCollapse)
+}
+
+// FilterIn represents the input for filtering content of a view.
+type FilterIn struct {
+	Mode FilterMode
+
+	// TrustDepth is the number of trust relations to follow from the perspective.
+	// With a depth of 0, only users blocked or marked as spam by the perspective are filtered.
+	// With a depth of 1, users blocked or marked as spam by those trusted by the perspective are also filtered.
+	TrustDepth int
+
+	// MinReputation (optional) filters users of lower reputation than specified.
+	// If set when mode is 'FilterNone', content is hidden.
+	MinReputation *float64
+}
+
+// Check checks the filter input.
+func (f *FilterIn) Check() error {
+	if _, e := GetFilterMode(string(f.Mode)); e != nil {
+		return e
+	}
+	if f.TrustDepth < 0 || f.TrustDepth > MaxTrustDepth {
+		return boo.Newf(boo.InvalidInput,
+			"invalid trust depth %d, valid values are between 0 and %d inclusive",
+			f.TrustDepth, MaxTrustDepth)
+	}
+	return nil
+}
+
+// contentFilter determines what content to filter from a view.
+type contentFilter struct {
+	mode  FilterMode
+	users map[string]struct{} // key (public key of user to filter)
+
+	reputation    map[string]float64
+	minReputation *float64
+}
+
+// newContentFilter generates a content filter from the perspective of the given user.
+// Should only be used when viewer is locked.
+func (v *Viewer) newContentFilter(perspective string, in *FilterIn) (*contentFilter, error) {
+	if e := in.Check(); e != nil {
+		return nil, e
+	}
+	f := &contentFilter{
+		mode:  in.Mode,
+		users: make(map[string]struct{}),
+	}
+	if in.MinReputation != nil {
+		if f.mode == FilterNone {
+			f.mode = FilterHide
+		}
+		f.reputation = v.reputation(perspective)
+		f.minReputation = in.MinReputation
+	}
+	origin, ok := v.c.profiles[perspective]
+	if in.Mode == FilterNone || !ok {
+		return f, nil
+	}
+
+	// Breadth-first walk of trust relations from perspective.
+	var (
+		visited = map[string]struct{}{perspective: {}}
+		level   = []string{perspective}
+	)
+	for depth := 0; depth <= in.TrustDepth && len(level) > 0; depth++ {
+		var next []string
+		for _, upk := range level {
+			profile, ok := v.c.profiles[upk]
+			if !ok {
+				continue
+			}
+			for blocked := range profile.Blocked {
+				f.users[blocked] = struct{}{}
+			}
+			for spammer := range profile.MarkedAsSpam {
+				f.users[spammer] = struct{}{}
+			}
+			for trusted := range profile.Trusted {
+				if _, has := visited[trusted]; !has {
+					visited[trusted] = struct{}{}
+					next = append(next, trusted)
+				}
+			}
+		}
+		level = next
+	}
+
+	// The perspective's own trust relations takes precedence.
+	delete(f.users, perspective)
+	for trusted := range origin.Trusted {
+		delete(f.users, trusted)
+	}
+	return f, nil
+}
+
+// apply obtains the representation of content after filter is applied.
+// Returns false if the content is to be removed from view.
+func (f *contentFilter) apply(rep *object.ContentRep) (*object.ContentRep, bool) {
+	if f == nil || f.mode == FilterNone || rep == nil {
+		return rep, true
+	}
+	body, ok := rep.Body.(*object.Body)
+	if !ok {
+		return rep, true
+	}
+	if !f.isFiltered(body.Creator) {
+		return rep, true
+	}
+	switch f.mode {
+	case FilterHide:
+		return nil, false
+	case FilterCollapse:
+		collapsed := *rep
+		collapsed.Collapsed = true
+		return &collapsed, true
+	default:
+		return rep, true
+	}
+}
+
+func (f *contentFilter) isFiltered(upk string) bool {
+	if _, filtered := f.users[upk]; filtered {
+		return true
+	}
+	return f.minReputation != nil && f.reputation[upk] < *f.minReputation
+}
@@ -0,0 +1,71 @@
+package state
+
+import (
+	"github.com/skycoin/bbs/src/store/object"
+	"testing"
+)
+
+func TestViewer_newContentFilter(t *testing.T) {
+	v := &Viewer{c: NewContainer()}
+
+	relate(v, "me", "friend", trusts)
+	relate(v, "me", "troll", blocks)
+	relate(v, "friend", "spammer", spams)
+	relate(v, "friend", "friend2", trusts)
+	relate(v, "friend2", "far", blocks)
+	relate(v, "friend2", "friend", blocks)
+
+	cases := []struct {
+		name     string
+		in       FilterIn
+		filtered []string
+		kept     []string
+	}{
+		{"none", FilterIn{Mode: FilterNone}, nil, []string{"troll", "spammer"}},
+		{"depth_0", FilterIn{Mode: FilterHide}, []string{"troll"}, []string{"spammer", "far"}},
+		{"depth_1", FilterIn{Mode: FilterHide, TrustDepth: 1}, []string{"troll", "spammer"}, []string{"far"}},
+		{"depth_2", FilterIn{Mode: FilterHide, TrustDepth: 2}, []string{"troll", "spammer", "far"}, []string{"friend", "me"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			f, e := v.newContentFilter("me", &c.in)
+			if e != nil {
+				t.Fatal(e)
+			}
+			for _, upk := range c.filtered {
+				rep := &object.ContentRep{Body: &object.Body{Creator: upk}}
+				if _, ok := f.apply(rep); ok {
+					t.Errorf("expected content of '%s' to be filtered", upk)
+				}
+			}
+			for _, upk := range c.kept {
+				rep := &object.ContentRep{Body: &object.Body{Creator: upk}}
+				if _, ok := f.apply(rep); !ok {
+					t.Errorf("expected content of '%s' to be kept", upk)
+				}
+			}
+		})
+	}
+
+	t.Run("collapse", func(t *testing.T) {
+		f, e := v.newContentFilter("me", &FilterIn{Mode: FilterCollapse})
+		if e != nil {
+			t.Fatal(e)
+		}
+		rep := &object.ContentRep{Body: &object.Body{Creator: "troll"}}
+		got, ok := f.apply(rep)
+		if !ok || !got.Collapsed {
+			t.Error("expected content to be kept and collapsed")
+		}
+		if rep.Collapsed {
+			t.Error("expected original content representation to be unchanged")
+		}
+	})
+
+	t.Run("invalid_depth", func(t *testing.T) {
+		if _, e := v.newContentFilter("me", &FilterIn{Mode: FilterHide, TrustDepth: MaxTrustDepth + 1}); e == nil {
+			t.Error("expected error for invalid trust depth")
+		}
+	})
+}
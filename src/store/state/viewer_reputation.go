@@ -0,0 +1,182 @@
+package state
+
+import (
+	"github.com/skycoin/bbs/src/store/object"
+	"math"
+	"sort"
+)
+
+// Parameters of reputation computation.
+const (
+	repDamping     = 0.85 // Probability of following a relation rather than returning to a seed.
+	repIterations  = 50   // Max number of iterations of trust propagation.
+	repTolerance   = 1e-9 // Iteration stops when the total change of scores is below this.
+	repTrustWeight = 1.0  // Weight of a trust, spam or block relation between users.
+	repVoteWeight  = 0.25 // Weight of a single vote on a user's thread or post.
+)
+
+// repGraph holds weighted relations between users.
+type repGraph struct {
+	pos map[string]map[string]float64 // key (from user), value (key (to user), value (weight))
+	neg map[string]map[string]float64 // key (from user), value (key (to user), value (weight))
+}
+
+func (g *repGraph) add(edges map[string]map[string]float64, from, to string, w float64) {
+	if from == to {
+		return
+	}
+	out, ok := edges[from]
+	if !ok {
+		out = make(map[string]float64)
+		edges[from] = out
+	}
+	out[to] += w
+}
+
+// reputationGraph generates relations between users from profiles and votes.
+// Should only be used when viewer is locked.
+func (v *Viewer) reputationGraph() *repGraph {
+	g := &repGraph{
+		pos: make(map[string]map[string]float64),
+		neg: make(map[string]map[string]float64),
+	}
+	for upk, profile := range v.c.profiles {
+		for trusted := range profile.Trusted {
+			g.add(g.pos, upk, trusted, repTrustWeight)
+		}
+		for spammer := range profile.MarkedAsSpam {
+			g.add(g.neg, upk, spammer, repTrustWeight)
+		}
+		for blocked := range profile.Blocked {
+			g.add(g.neg, upk, blocked, repTrustWeight)
+		}
+	}
+	for ref, votes := range v.c.votes {
+		rep, ok := v.c.content[ref]
+		if !ok {
+			continue
+		}
+		body, ok := rep.Body.(*object.Body)
+		if !ok {
+			continue
+		}
+		for voter, vote := range votes.Votes {
+			switch value := votes.GetValue(vote); {
+			case value > 0:
+				g.add(g.pos, voter, body.Creator, repVoteWeight)
+			case value < 0:
+				g.add(g.neg, voter, body.Creator, repVoteWeight)
+			}
+		}
+	}
+	return g
+}
+
+// computeReputation propagates trust from the seed users through positive
+// relations (personalised page rank). Negative relations then subtract a
+// portion of the rank of the users who made them.
+// Scores are scaled so that an average participant has a reputation of 1.
+// Should only be used when viewer is locked.
+func (v *Viewer) computeReputation(seeds ...string) map[string]float64 {
+	g := v.reputationGraph()
+
+	teleport := make(map[string]float64, len(seeds))
+	for _, seed := range seeds {
+		teleport[seed] = 1 / float64(len(seeds))
+	}
+
+	nodes := make(map[string]struct{}, len(v.c.profiles)+len(seeds))
+	for upk := range v.c.profiles {
+		nodes[upk] = struct{}{}
+	}
+	for _, seed := range seeds {
+		nodes[seed] = struct{}{}
+	}
+
+	rank := make(map[string]float64, len(nodes))
+	for seed, value := range teleport {
+		rank[seed] = value
+	}
+
+	for i := 0; i < repIterations; i++ {
+		var (
+			next     = make(map[string]float64, len(nodes))
+			dangling float64
+		)
+		for upk, r := range rank {
+			out := g.pos[upk]
+			var total float64
+			for _, w := range out {
+				total += w
+			}
+			if total == 0 {
+				dangling += r
+				continue
+			}
+			for to, w := range out {
+				next[to] += repDamping * r * w / total
+			}
+		}
+		for seed, value := range teleport {
+			next[seed] += (1-repDamping)*value + repDamping*dangling*value
+		}
+		var delta float64
+		for upk := range nodes {
+			delta += math.Abs(next[upk] - rank[upk])
+		}
+		rank = next
+		if delta < repTolerance {
+			break
+		}
+	}
+
+	out := make(map[string]float64, len(nodes))
+	for upk := range nodes {
+		out[upk] = rank[upk]
+	}
+	for from, edges := range g.neg {
+		var total float64
+		for _, w := range edges {
+			total += w
+		}
+		for to, w := range edges {
+			out[to] -= rank[from] * w / total
+		}
+	}
+	for upk := range out {
+		out[upk] *= float64(len(nodes))
+	}
+	return out
+}
+
+// reputation obtains reputation scores seeded by the board owner, and the
+// perspective (if specified and the perspective is a participant of the board).
+// Should only be used when viewer is locked.
+func (v *Viewer) reputation(perspective string) map[string]float64 {
+	owner := v.pk.Hex()
+	if _, ok := v.c.profiles[perspective]; ok && perspective != owner {
+		return v.computeReputation(owner, perspective)
+	}
+	v.repMux.Lock()
+	defer v.repMux.Unlock()
+	if v.rep == nil {
+		v.rep = v.computeReputation(owner)
+	}
+	return v.rep
+}
+
+// sortByReputation orders hashes of content by the reputation of their creators.
+// Should only be used when viewer is locked.
+func (v *Viewer) sortByReputation(hashes []string, reputation map[string]float64) {
+	creatorRep := func(hash string) float64 {
+		if rep, ok := v.c.content[hash]; ok {
+			if body, ok := rep.Body.(*object.Body); ok {
+				return reputation[body.Creator]
+			}
+		}
+		return 0
+	}
+	sort.SliceStable(hashes, func(i, j int) bool {
+		return creatorRep(hashes[i]) > creatorRep(hashes[j])
+	})
+}
@@ -0,0 +1,57 @@
+package state
+
+import (
+	"github.com/skycoin/skycoin/src/cipher"
+	"testing"
+)
+
+func TestViewer_reputation(t *testing.T) {
+	pk, _ := cipher.GenerateKeyPair()
+	v := &Viewer{pk: pk, i: NewIndexer(), c: NewContainer()}
+	owner := pk.Hex()
+
+	relate(v, owner, "friend", trusts)
+	relate(v, "friend", "friend2", trusts)
+	relate(v, "stranger", "stranger2", trusts)
+	relate(v, owner, "troll", blocks)
+
+	rep := v.reputation("")
+
+	if !(rep["friend"] > rep["friend2"]) {
+		t.Errorf("expected direct trust to outrank indirect trust: %v", rep)
+	}
+	if !(rep["friend2"] > rep["stranger2"]) {
+		t.Errorf("expected trusted user to outrank stranger: %v", rep)
+	}
+	if !(rep["troll"] < 0) {
+		t.Errorf("expected blocked user to have negative reputation: %v", rep)
+	}
+
+	t.Run("perspective", func(t *testing.T) {
+		rep := v.reputation("stranger")
+		if !(rep["stranger2"] > 0) {
+			t.Errorf("expected user trusted by perspective to have reputation: %v", rep)
+		}
+	})
+
+	t.Run("new_participant", func(t *testing.T) {
+		v.ensureUser("newcomer")
+		if _, ok := v.reputation("")["newcomer"]; !ok {
+			t.Error("expected cached reputation to include new participant")
+		}
+	})
+
+	t.Run("min_reputation", func(t *testing.T) {
+		minRep := 0.0
+		f, e := v.newContentFilter("", &FilterIn{MinReputation: &minRep})
+		if e != nil {
+			t.Fatal(e)
+		}
+		if !f.isFiltered("troll") {
+			t.Error("expected blocked user to be filtered")
+		}
+		if f.isFiltered("friend") {
+			t.Error("expected trusted user to be kept")
+		}
+	})
+}
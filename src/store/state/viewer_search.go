@@ -0,0 +1,175 @@
+package state
+
+import (
+	"github.com/skycoin/bbs/src/misc/boo"
+	"github.com/skycoin/bbs/src/store/object"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Weights of the fields of indexed content.
+const (
+	searchWeightName   = 3
+	searchWeightBody   = 1
+	searchWeightAuthor = 2
+)
+
+/*
+	<<< SEARCH INDEX >>>
+*/
+
+// SearchIndex is an inverted index of the textual content of a board.
+type SearchIndex struct {
+	terms map[string]map[string]int // key (term), value (key (content hash), value (weighted term frequency))
+	docs  map[string]struct{}       // key (content hash)
+}
+
+// NewSearchIndex creates a new SearchIndex.
+func NewSearchIndex() *SearchIndex {
+	return &SearchIndex{
+		terms: make(map[string]map[string]int),
+		docs:  make(map[string]struct{}),
+	}
+}
+
+// Add indexes the name, body and creator of a thread or post.
+// Boards do not record aliases of users, hence authors are searched by public key.
+func (s *SearchIndex) Add(cHash string, b *object.Body) {
+	if _, has := s.docs[cHash]; has {
+		return
+	}
+	s.docs[cHash] = struct{}{}
+	s.addField(cHash, b.Name, searchWeightName)
+	s.addField(cHash, b.Body, searchWeightBody)
+	s.addField(cHash, b.Creator, searchWeightAuthor)
+}
+
+func (s *SearchIndex) addField(cHash, text string, weight int) {
+	for _, term := range SearchTerms(text) {
+		postings, ok := s.terms[term]
+		if !ok {
+			postings = make(map[string]int)
+			s.terms[term] = postings
+		}
+		postings[cHash] += weight
+	}
+}
+
+// SearchHit represents a content that matches a search query.
+type SearchHit struct {
+	Hash  string
+	Score float64
+}
+
+// Find obtains the content that contains all terms of the query.
+// Hits are ranked with the tf-idf of the query terms.
+func (s *SearchIndex) Find(terms []string) []SearchHit {
+	if len(terms) == 0 {
+		return nil
+	}
+	scores := make(map[string]float64)
+	for i, term := range terms {
+		postings := s.terms[term]
+		if len(postings) == 0 {
+			return nil
+		}
+		idf := math.Log(1 + float64(len(s.docs))/float64(len(postings)))
+		next := make(map[string]float64)
+		for cHash, tf := range postings {
+			if score, ok := scores[cHash]; ok || i == 0 {
+				next[cHash] = score + float64(tf)*idf
+			}
+		}
+		scores = next
+	}
+	out := make([]SearchHit, 0, len(scores))
+	for cHash, score := range scores {
+		out = append(out, SearchHit{Hash: cHash, Score: score})
+	}
+	return out
+}
+
+// SearchTerms splits text into lower-case search terms.
+func SearchTerms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+/*
+	<<< VIEWER >>>
+*/
+
+// SearchIn represents the input required to search a board.
+type SearchIn struct {
+	Perspective string
+	Query       string
+}
+
+// SearchResult represents a single content that matches a search query.
+type SearchResult struct {
+	BoardPubKey string             `json:"board_public_key"`
+	ThreadHash  string             `json:"thread_hash"`
+	Score       float64            `json:"score"`
+	Content     *object.ContentRep `json:"content"`
+}
+
+// SearchOut represents the output of a board search.
+type SearchOut struct {
+	Results []*SearchResult `json:"results"`
+}
+
+// Search finds threads and posts of the board that match the query.
+// Results are ordered by score, then by time of creation.
+func (v *Viewer) Search(in *SearchIn) (*SearchOut, error) {
+	if v == nil {
+		return nil, ErrViewerNotInitialized
+	}
+	terms := SearchTerms(in.Query)
+	if len(terms) == 0 {
+		return nil, boo.New(boo.InvalidInput, "search query has no terms")
+	}
+	defer v.rLock()()
+
+	hits := v.s.Find(terms)
+	out := &SearchOut{
+		Results: make([]*SearchResult, 0, len(hits)),
+	}
+	for _, hit := range hits {
+		rep, ok := v.c.content[hit.Hash]
+		if !ok {
+			continue
+		}
+		result := &SearchResult{
+			BoardPubKey: v.pk.Hex(),
+			ThreadHash:  hit.Hash,
+			Score:       hit.Score,
+			Content:     v.view(hit.Hash, rep, in.Perspective),
+		}
+		if body, ok := rep.Body.(*object.Body); ok && body.Type == object.V5PostType {
+			result.ThreadHash = body.OfThread
+		}
+		out.Results = append(out.Results, result)
+	}
+	SortSearchResults(out.Results)
+	return out, nil
+}
+
+// SortSearchResults orders search results by score, then by time of creation.
+func SortSearchResults(results []*SearchResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return searchResultTS(results[i]) > searchResultTS(results[j])
+	})
+}
+
+func searchResultTS(r *SearchResult) int64 {
+	if body, ok := r.Content.Body.(*object.Body); ok {
+		return body.TS
+	}
+	return 0
+}
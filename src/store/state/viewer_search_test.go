@@ -0,0 +1,49 @@
+package state
+
+import (
+	"github.com/skycoin/bbs/src/store/object"
+	"github.com/skycoin/skycoin/src/cipher"
+	"strings"
+	"testing"
+)
+
+func TestSearchIndex_Find(t *testing.T) {
+	alice, _ := cipher.GenerateDeterministicKeyPair([]byte("alice"))
+	bob, _ := cipher.GenerateDeterministicKeyPair([]byte("bob"))
+
+	s := NewSearchIndex()
+	s.Add("t1", &object.Body{Name: "Hello World", Body: "first thread", Creator: alice.Hex()})
+	s.Add("p1", &object.Body{Name: "Re: Hello", Body: "hello, hello again!", Creator: bob.Hex()})
+	s.Add("p2", &object.Body{Name: "Other", Body: "nothing to see", Creator: alice.Hex()})
+
+	t.Run("single_term", func(t *testing.T) {
+		hits := s.Find(SearchTerms("HELLO"))
+		if len(hits) != 2 {
+			t.Fatalf("got %d hits, expected 2", len(hits))
+		}
+	})
+
+	t.Run("all_terms_required", func(t *testing.T) {
+		hits := s.Find(SearchTerms("hello thread"))
+		if len(hits) != 1 || hits[0].Hash != "t1" {
+			t.Fatalf("got %v, expected only 't1'", hits)
+		}
+	})
+
+	t.Run("author", func(t *testing.T) {
+		hits := s.Find(SearchTerms(alice.Hex()))
+		if len(hits) != 2 {
+			t.Fatalf("got %d hits, expected 2", len(hits))
+		}
+		hits = s.Find(SearchTerms(strings.ToUpper(bob.Hex()) + " hello"))
+		if len(hits) != 1 || hits[0].Hash != "p1" {
+			t.Fatalf("got %v, expected only 'p1'", hits)
+		}
+	})
+
+	t.Run("no_match", func(t *testing.T) {
+		if hits := s.Find(SearchTerms("missing")); len(hits) != 0 {
+			t.Fatalf("got %v, expected no hits", hits)
+		}
+	})
+}
@@ -0,0 +1,142 @@
+package state
+
+import (
+	"github.com/skycoin/bbs/src/misc/boo"
+	"github.com/skycoin/bbs/src/misc/typ"
+	"github.com/skycoin/bbs/src/misc/typ/paginatedtypes"
+	"math"
+	"time"
+)
+
+// SortMode determines the order in which threads of a board page are listed.
+type SortMode string
+
+const (
+	SortNone   = SortMode("")       // Order in which threads are added to the board.
+	SortBumped = SortMode("bumped") // Most recent activity (thread or post) first.
+	SortHot    = SortMode("hot")    // Highest vote score, decayed with age, first.
+	SortTop    = SortMode("top")    // Highest vote score first.
+	SortNew    = SortMode("new")    // Most recently created first.
+
+	// SortReputation orders content by the reputation of it's creator (highest first).
+	// As reputation depends on perspective, this order is generated per request.
+	SortReputation = SortMode("reputation")
+)
+
+// hotDecay is the number of seconds a thread needs to be younger than another
+// to out-rank a thread with 10x it's vote score.
+const hotDecay = 45000
+
+// IsValid checks whether the sort mode is known.
+func (m SortMode) IsValid() bool {
+	switch m {
+	case SortNone, SortBumped, SortHot, SortTop, SortNew, SortReputation:
+		return true
+	}
+	return false
+}
+
+// GetSortMode obtains a sort mode from string.
+func GetSortMode(v string) (SortMode, error) {
+	if m := SortMode(v); m.IsValid() {
+		return m, nil
+	}
+	return SortNone, boo.Newf(boo.InvalidInput,
+		"invalid sort mode '%s', valid modes are: '%s', '%s', '%s', '%s', '%s'",
+		v, SortBumped, SortHot, SortTop, SortNew, SortReputation)
+}
+
+// ThreadRank holds the values used to order threads in sorted board views.
+type ThreadRank struct {
+	Created int64 // Creation time of thread (unix nano).
+	Bumped  int64 // Time of last activity within thread (unix nano).
+	Score   int   // Up votes minus down votes.
+}
+
+// Hot obtains the time-decayed vote score of the thread.
+func (r *ThreadRank) Hot() float64 {
+	var (
+		order = math.Log10(math.Max(math.Abs(float64(r.Score)), 1))
+		sign  float64
+	)
+	switch {
+	case r.Score > 0:
+		sign = 1
+	case r.Score < 0:
+		sign = -1
+	}
+	return sign*order + float64(r.Created/int64(time.Second))/hotDecay
+}
+
+// threadSorters generates the sorted thread indexes for each sort mode.
+func (i *Indexer) threadSorters() map[SortMode]*paginatedtypes.Sorted {
+	rank := func(tHash string) *ThreadRank {
+		if r, ok := i.ThreadRanks[tHash]; ok {
+			return r
+		}
+		return new(ThreadRank)
+	}
+	return map[SortMode]*paginatedtypes.Sorted{
+		SortBumped: paginatedtypes.NewSorted(func(a, b string) bool {
+			return rank(a).Bumped > rank(b).Bumped
+		}),
+		SortHot: paginatedtypes.NewSorted(func(a, b string) bool {
+			return rank(a).Hot() > rank(b).Hot()
+		}),
+		SortTop: paginatedtypes.NewSorted(func(a, b string) bool {
+			if ra, rb := rank(a), rank(b); ra.Score != rb.Score {
+				return ra.Score > rb.Score
+			} else {
+				return ra.Created > rb.Created
+			}
+		}),
+		SortNew: paginatedtypes.NewSorted(func(a, b string) bool {
+			return rank(a).Created > rank(b).Created
+		}),
+	}
+}
+
+// GetThreads obtains the thread index of given sort mode.
+func (i *Indexer) GetThreads(mode SortMode) (typ.Paginated, error) {
+	if mode == SortNone {
+		return i.Threads, nil
+	}
+	if sorted, ok := i.SortedThreads[mode]; ok {
+		return sorted, nil
+	}
+	return nil, boo.Newf(boo.InvalidInput, "invalid sort mode '%s'", mode)
+}
+
+// AddThreadRank indexes a new thread in all sorted thread indexes.
+func (i *Indexer) AddThreadRank(tHash string, created int64) {
+	i.ThreadRanks[tHash] = &ThreadRank{
+		Created: created,
+		Bumped:  created,
+	}
+	for _, sorted := range i.SortedThreads {
+		sorted.Append(tHash)
+	}
+}
+
+// BumpThread records activity within a thread.
+func (i *Indexer) BumpThread(tHash string, ts int64) {
+	r, ok := i.ThreadRanks[tHash]
+	if !ok || r.Bumped >= ts {
+		return
+	}
+	i.SortedThreads[SortBumped].Update(tHash, func() { r.Bumped = ts })
+}
+
+// ScoreThread records a new vote score of a thread.
+func (i *Indexer) ScoreThread(tHash string, score int) {
+	r, ok := i.ThreadRanks[tHash]
+	if !ok || r.Score == score {
+		return
+	}
+	hot, top := i.SortedThreads[SortHot], i.SortedThreads[SortTop]
+	hot.Remove(tHash)
+	top.Remove(tHash)
+	r.Score = score
+	hot.Append(tHash)
+	top.Append(tHash)
+}
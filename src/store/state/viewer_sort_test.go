@@ -0,0 +1,76 @@
+package state
+
+import (
+	"fmt"
+	"github.com/skycoin/bbs/src/misc/typ"
+	"math"
+	"testing"
+)
+
+func TestViewer_GetBoardPage_SortMode(t *testing.T) {
+	const (
+		boardSeed = "a"
+		userSeed  = "b"
+	)
+
+	bi, quit := initInstance(t, boardSeed)
+	defer quit()
+
+	t0, _ := addThread(t, bi, 0, []byte(userSeed))
+	t1, _ := addThread(t, bi, 1, []byte(userSeed))
+
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+
+	addPost(t, bi, t0, 0, []byte(userSeed))
+
+	// Older thread out-ranks newer thread with a vote score of 10.
+	for i := 0; i < 10; i++ {
+		addThreadVote(t, bi, t0, +1, []byte(fmt.Sprintf("voter %d", i)))
+	}
+
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+
+	cases := []struct {
+		mode     SortMode
+		expected []string
+	}{
+		{SortNone, []string{t0.Hex(), t1.Hex()}},
+		{SortNew, []string{t1.Hex(), t0.Hex()}},
+		{SortBumped, []string{t0.Hex(), t1.Hex()}},
+		{SortHot, []string{t0.Hex(), t1.Hex()}},
+		{SortTop, []string{t0.Hex(), t1.Hex()}},
+	}
+
+	for _, c := range cases {
+		t.Run("mode_"+string(c.mode), func(t *testing.T) {
+			out, e := bi.Viewer().GetBoardPage(&BoardPageIn{
+				SortMode:       c.mode,
+				PaginatedInput: typ.PaginatedInput{PageSize: math.MaxUint64},
+			})
+			if e != nil {
+				t.Fatal(e)
+			}
+			if len(out.Threads) != len(c.expected) {
+				t.Fatalf("got %d threads, expected %d", len(out.Threads), len(c.expected))
+			}
+			for i, tHash := range c.expected {
+				if got := out.Threads[i].Header.Hash; got != tHash {
+					t.Errorf("[%d] got thread '%s', expected '%s'", i, got, tHash)
+				}
+			}
+		})
+	}
+
+	t.Run("invalid_mode", func(t *testing.T) {
+		if _, e := bi.Viewer().GetBoardPage(&BoardPageIn{
+			SortMode:       SortMode("random"),
+			PaginatedInput: typ.PaginatedInput{PageSize: math.MaxUint64},
+		}); e == nil {
+			t.Error("expected error for invalid sort mode")
+		}
+	})
+}
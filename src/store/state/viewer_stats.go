@@ -0,0 +1,163 @@
+package state
+
+import (
+	"github.com/skycoin/bbs/src/misc/boo"
+	"github.com/skycoin/bbs/src/store/object"
+	"sort"
+	"time"
+)
+
+const (
+	DefaultStatsDays     = 30  // Default number of days of the posts per day window.
+	DefaultStatsTopCount = 10  // Default number of top contributors.
+	MaxStatsDays         = 366 // Max number of days of the posts per day window.
+)
+
+// BoardStatsIn represents the input required to obtain board statistics.
+type BoardStatsIn struct {
+	Days     int   // Number of days of the posts per day window (including today).
+	TopCount int   // Max number of top contributors.
+	Now      int64 // End of posts per day window (unix nano), uses current time if 0.
+}
+
+// DailyCount represents the number of posts of a day (UTC).
+type DailyCount struct {
+	Day   string `json:"day"` // Formatted as 'YYYY-MM-DD'.
+	Count int    `json:"count"`
+}
+
+// Contributor represents a user and the number of threads and posts the user has created.
+type Contributor struct {
+	UserPubKey string `json:"user_public_key"`
+	Threads    int    `json:"threads"`
+	Posts      int    `json:"posts"`
+}
+
+// VoteTotals represents the total votes of a board.
+type VoteTotals struct {
+	ThreadUp   int `json:"thread_up_votes"`
+	ThreadDown int `json:"thread_down_votes"`
+	PostUp     int `json:"post_up_votes"`
+	PostDown   int `json:"post_down_votes"`
+}
+
+// BoardStatsOut represents the statistics of a board.
+type BoardStatsOut struct {
+	BoardPubKey      string         `json:"board_public_key"`
+	ThreadCount      int            `json:"thread_count"`
+	PostCount        int            `json:"post_count"`
+	ParticipantCount int            `json:"participant_count"`
+	PostsPerDay      []*DailyCount  `json:"posts_per_day"` // Oldest day first.
+	TopContributors  []*Contributor `json:"top_contributors"`
+	Votes            VoteTotals     `json:"votes"`
+	LastActivity     int64          `json:"last_activity"` // Time of last thread, post or vote (unix nano).
+}
+
+// GetBoardStats obtains statistics of the board.
+func (v *Viewer) GetBoardStats(in *BoardStatsIn) (*BoardStatsOut, error) {
+	if v == nil {
+		return nil, ErrViewerNotInitialized
+	}
+	nDays, topCount := in.Days, in.TopCount
+	if nDays == 0 {
+		nDays = DefaultStatsDays
+	}
+	if nDays < 0 || nDays > MaxStatsDays {
+		return nil, boo.Newf(boo.InvalidInput,
+			"invalid number of days %d, valid values are between 1 and %d inclusive",
+			nDays, MaxStatsDays)
+	}
+	if topCount <= 0 {
+		topCount = DefaultStatsTopCount
+	}
+	now := time.Now().UTC()
+	if in.Now != 0 {
+		now = time.Unix(0, in.Now).UTC()
+	}
+	defer v.rLock()()
+
+	out := &BoardStatsOut{
+		BoardPubKey:      v.pk.Hex(),
+		ParticipantCount: v.i.Users.Len(),
+		PostsPerDay:      make([]*DailyCount, nDays),
+	}
+
+	// Prepare posts per day window.
+	var (
+		lastDay = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		days    = make(map[string]*DailyCount, nDays)
+	)
+	for i := range out.PostsPerDay {
+		day := lastDay.AddDate(0, 0, i-nDays+1).Format("2006-01-02")
+		out.PostsPerDay[i] = &DailyCount{Day: day}
+		days[day] = out.PostsPerDay[i]
+	}
+
+	// Count threads and posts.
+	contributors := make(map[string]*Contributor)
+	contributor := func(upk string) *Contributor {
+		c, ok := contributors[upk]
+		if !ok {
+			c = &Contributor{UserPubKey: upk}
+			contributors[upk] = c
+		}
+		return c
+	}
+	for _, rep := range v.c.content {
+		body, ok := rep.Body.(*object.Body)
+		if !ok {
+			continue
+		}
+		switch body.Type {
+		case object.V5ThreadType:
+			out.ThreadCount++
+			contributor(body.Creator).Threads++
+		case object.V5PostType:
+			out.PostCount++
+			contributor(body.Creator).Posts++
+			day := time.Unix(0, body.TS).UTC().Format("2006-01-02")
+			if dc, ok := days[day]; ok {
+				dc.Count++
+			}
+		default:
+			continue
+		}
+		if body.TS > out.LastActivity {
+			out.LastActivity = body.TS
+		}
+	}
+
+	// Count votes.
+	for _, votes := range v.c.votes {
+		switch votes.Type {
+		case object.V5ThreadVoteType:
+			out.Votes.ThreadUp += votes.UpCount
+			out.Votes.ThreadDown += votes.DownCount
+		case object.V5PostVoteType:
+			out.Votes.PostUp += votes.UpCount
+			out.Votes.PostDown += votes.DownCount
+		}
+		for _, vote := range votes.Votes {
+			if ts := vote.GetBody().TS; ts > out.LastActivity {
+				out.LastActivity = ts
+			}
+		}
+	}
+
+	// Top contributors.
+	out.TopContributors = make([]*Contributor, 0, len(contributors))
+	for _, c := range contributors {
+		out.TopContributors = append(out.TopContributors, c)
+	}
+	sort.Slice(out.TopContributors, func(i, j int) bool {
+		a, b := out.TopContributors[i], out.TopContributors[j]
+		if a.Threads+a.Posts != b.Threads+b.Posts {
+			return a.Threads+a.Posts > b.Threads+b.Posts
+		}
+		return a.UserPubKey < b.UserPubKey
+	})
+	if len(out.TopContributors) > topCount {
+		out.TopContributors = out.TopContributors[:topCount]
+	}
+	return out, nil
+}
@@ -0,0 +1,57 @@
+package state
+
+import (
+	"github.com/skycoin/skycoin/src/cipher"
+	"testing"
+)
+
+func TestViewer_GetBoardStats(t *testing.T) {
+	const (
+		boardSeed = "a"
+		userSeedA = "b"
+		userSeedB = "c"
+	)
+
+	bi, quit := initInstance(t, boardSeed)
+	defer quit()
+
+	tHash, _ := addThread(t, bi, 0, []byte(userSeedA))
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+	addPost(t, bi, tHash, 0, []byte(userSeedA))
+	addPost(t, bi, tHash, 1, []byte(userSeedB))
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+
+	out, e := bi.Viewer().GetBoardStats(&BoardStatsIn{Days: 7, TopCount: 1})
+	if e != nil {
+		t.Fatal(e)
+	}
+	if out.ThreadCount != 1 || out.PostCount != 2 {
+		t.Errorf("got %d threads and %d posts, expected 1 and 2", out.ThreadCount, out.PostCount)
+	}
+	if out.ParticipantCount != 2 {
+		t.Errorf("got %d participants, expected 2", out.ParticipantCount)
+	}
+	if out.LastActivity == 0 {
+		t.Fatal("expected last activity to be set")
+	}
+
+	// Window ending at last activity should include all posts on it's last day.
+	out, e = bi.Viewer().GetBoardStats(&BoardStatsIn{Days: 7, TopCount: 1, Now: out.LastActivity})
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(out.PostsPerDay) != 7 || out.PostsPerDay[6].Count != 2 {
+		t.Errorf("expected 2 posts on last day of 7 day window: %v", out.PostsPerDay)
+	}
+	upkA, _ := cipher.GenerateDeterministicKeyPair([]byte(userSeedA))
+	if len(out.TopContributors) != 1 || out.TopContributors[0].UserPubKey != upkA.Hex() {
+		t.Errorf("expected single top contributor '%s': %v", upkA.Hex(), out.TopContributors)
+	}
+	if _, e := bi.Viewer().GetBoardStats(&BoardStatsIn{Days: MaxStatsDays + 1}); e == nil {
+		t.Error("expected error for invalid number of days")
+	}
+}
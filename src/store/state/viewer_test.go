@@ -0,0 +1,116 @@
+package state
+
+import (
+	"encoding/json"
+	"github.com/skycoin/bbs/src/misc/typ"
+	"github.com/skycoin/bbs/src/store/object"
+	"github.com/skycoin/skycoin/src/cipher"
+	"math"
+	"sync"
+	"testing"
+	"time"
+)
+
+func addThreadVote(t *testing.T, bi *BoardInstance, threadHash cipher.SHA256, value int, userSeed []byte) {
+	cpk, csk := cipher.GenerateDeterministicKeyPair(userSeed)
+	body := &object.Body{
+		Type:     object.V5ThreadVoteType,
+		TS:       time.Now().UnixNano(),
+		OfBoard:  obtainBoardPubKey(t, bi).Hex(),
+		OfThread: threadHash.Hex(),
+		Value:    value,
+		Creator:  cpk.Hex(),
+	}
+	raw, _ := json.Marshal(body)
+	sig := cipher.SignHash(cipher.SumSHA256(raw), csk)
+	transport, e := object.NewTransport(raw, sig)
+	if e != nil {
+		t.Fatal("failed to generate transport:", e)
+	}
+	if _, e := bi.Submit(transport); e != nil {
+		t.Fatal("failed to vote thread:", e)
+	}
+}
+
+// relate records a relation of user 'from' to user 'to' in the viewer's profiles,
+// where 'set' obtains the relation's set of the profile (e.g. trusts).
+func relate(v *Viewer, from, to string, set func(p *Profile) map[string]struct{}) {
+	set(v.c.GetProfile(from))[to] = struct{}{}
+	v.c.GetProfile(to)
+}
+
+func trusts(p *Profile) map[string]struct{} { return p.Trusted }
+func blocks(p *Profile) map[string]struct{} { return p.Blocked }
+func spams(p *Profile) map[string]struct{}  { return p.MarkedAsSpam }
+
+func TestViewer_GetBoardPage_Perspectives(t *testing.T) {
+	const (
+		boardSeed = "a"
+		voterSeed = "b"
+		otherSeed = "c"
+	)
+
+	bi, quit := initInstance(t, boardSeed)
+	defer quit()
+
+	tHash, _ := addThread(t, bi, 0, []byte(voterSeed))
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+	addThreadVote(t, bi, tHash, +1, []byte(voterSeed))
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+
+	voter, _ := cipher.GenerateDeterministicKeyPair([]byte(voterSeed))
+	other, _ := cipher.GenerateDeterministicKeyPair([]byte(otherSeed))
+
+	getPage := func(perspective string) *BoardPageOut {
+		out, e := bi.Viewer().GetBoardPage(&BoardPageIn{
+			Perspective:    perspective,
+			PaginatedInput: typ.PaginatedInput{PageSize: math.MaxUint64},
+		})
+		if e != nil {
+			t.Fatal(e)
+		}
+		return out
+	}
+	voted := func(out *BoardPageOut) bool {
+		return out.Threads[0].Votes.(*VoteRepView).Up.Voted
+	}
+
+	t.Run("isolated", func(t *testing.T) {
+		voterPage := getPage(voter.Hex())
+		otherPage := getPage(other.Hex())
+		if !voted(voterPage) {
+			t.Error("expected voter's response to show vote")
+		}
+		if voted(otherPage) {
+			t.Error("expected other's response to not show vote")
+		}
+		if rep := bi.v.c.content[tHash.Hex()]; rep.Votes != nil {
+			t.Error("expected stored content to be unmodified")
+		}
+	})
+
+	t.Run("parallel", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				perspective, exp := voter.Hex(), true
+				if i%2 == 1 {
+					perspective, exp = other.Hex(), false
+				}
+				for j := 0; j < 50; j++ {
+					if voted(getPage(perspective)) != exp {
+						t.Errorf("unexpected vote flag for perspective %s", perspective)
+						return
+					}
+				}
+			}(i)
+		}
+		wg.Wait()
+	})
+}
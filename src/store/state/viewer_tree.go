@@ -0,0 +1,208 @@
+package state
+
+import (
+	"github.com/skycoin/bbs/src/misc/boo"
+	"github.com/skycoin/bbs/src/store/object"
+)
+
+const (
+	DefaultTreeDepth    = 5  // Default number of reply levels to include in a thread tree.
+	MaxTreeDepth        = 32 // Max number of reply levels to include in a thread tree.
+	DefaultTreePageSize = 20 // Default max number of replies of each post to include.
+)
+
+// ThreadTreeIn represents the input required to obtain a thread as a reply tree.
+type ThreadTreeIn struct {
+	Perspective string
+	ThreadHash  string
+	Filter      FilterIn
+
+	// RootHash (optional) is the hash of the post in which to start the tree from.
+	// This is used to expand collapsed subtrees. Leave empty to start from the thread.
+	RootHash string
+
+	MaxDepth   int      // Number of reply levels to include below root.
+	StartIndex int      // Index of first reply of root to include.
+	PageSize   int      // Max number of replies of each post (or root) to include.
+	Collapsed  []string // Hashes of posts in which replies are to be excluded.
+}
+
+// PostNode represents a post and it's replies.
+type PostNode struct {
+	Post        *object.ContentRep `json:"post"`
+	ReplyCount  int                `json:"reply_count"`            // Number of direct replies.
+	Replies     []*PostNode        `json:"replies,omitempty"`      // Included replies.
+	MoreReplies int                `json:"more_replies,omitempty"` // Number of replies excluded by pagination.
+	Collapsed   bool               `json:"collapsed,omitempty"`    // Whether replies are excluded (collapsed or max depth reached).
+}
+
+// ThreadTreeOut represents the output for a thread as a reply tree.
+type ThreadTreeOut struct {
+	Board      *object.ContentRep `json:"board"`
+	Thread     *object.ContentRep `json:"thread"`
+	Root       string             `json:"root"`        // Hash of thread, or post in which the tree starts from.
+	TotalCount int                `json:"total_count"` // Number of direct replies of root.
+	StartIndex int                `json:"start_index"`
+	Replies    []*PostNode        `json:"replies"`
+}
+
+// GetThreadTree obtains the posts of a thread as a tree of replies.
+func (v *Viewer) GetThreadTree(in *ThreadTreeIn) (*ThreadTreeOut, error) {
+	if v == nil {
+		return nil, ErrViewerNotInitialized
+	}
+	depth, pageSize := in.MaxDepth, in.PageSize
+	if depth == 0 {
+		depth = DefaultTreeDepth
+	}
+	if depth < 0 || depth > MaxTreeDepth {
+		return nil, boo.Newf(boo.InvalidInput,
+			"invalid max depth %d, valid values are between 1 and %d inclusive",
+			depth, MaxTreeDepth)
+	}
+	if pageSize <= 0 {
+		pageSize = DefaultTreePageSize
+	}
+	if in.StartIndex < 0 {
+		return nil, boo.Newf(boo.InvalidInput, "invalid start index %d", in.StartIndex)
+	}
+	defer v.rLock()()
+
+	filter, e := v.newContentFilter(in.Perspective, &in.Filter)
+	if e != nil {
+		return nil, e
+	}
+	out := &ThreadTreeOut{
+		Board:      v.c.content[v.i.Board],
+		Root:       in.ThreadHash,
+		StartIndex: in.StartIndex,
+	}
+	if thread := v.c.content[in.ThreadHash]; thread == nil {
+		return nil, boo.Newf(boo.NotFound, "thread of hash '%s' is not found in board '%s'",
+			in.ThreadHash, v.pk.Hex())
+	} else if thread, _ = filter.apply(thread); thread == nil {
+		return nil, boo.Newf(boo.NotFound, "thread of hash '%s' is filtered from perspective",
+			in.ThreadHash)
+	} else {
+		out.Thread = v.view(in.ThreadHash, thread, in.Perspective)
+	}
+
+	var children []string
+	if in.RootHash == "" || in.RootHash == in.ThreadHash {
+		children = v.topLevelPosts(in.ThreadHash)
+	} else {
+		if body, ok := bodyOf(v.c.content[in.RootHash]); !ok || body.OfThread != in.ThreadHash {
+			return nil, boo.Newf(boo.NotFound, "post of hash '%s' is not found in thread '%s'",
+				in.RootHash, in.ThreadHash)
+		}
+		out.Root = in.RootHash
+		children = v.repliesOf(in.RootHash)
+	}
+
+	collapsed := make(map[string]struct{}, len(in.Collapsed))
+	for _, pHash := range in.Collapsed {
+		collapsed[pHash] = struct{}{}
+	}
+	b := &treeBuilder{
+		v:           v,
+		perspective: in.Perspective,
+		filter:      filter,
+		collapsed:   collapsed,
+		maxDepth:    depth,
+		pageSize:    pageSize,
+	}
+	out.TotalCount = len(children)
+	out.Replies, _ = b.level(children, in.StartIndex, 1)
+	return out, nil
+}
+
+// treeBuilder builds reply trees of a thread.
+type treeBuilder struct {
+	v           *Viewer
+	perspective string
+	filter      *contentFilter
+	collapsed   map[string]struct{}
+	maxDepth    int
+	pageSize    int
+}
+
+// level builds nodes of the given posts starting from index 'start' (limited by page size).
+// Returns the nodes and the number of posts excluded after the page.
+func (b *treeBuilder) level(pHashes []string, start, depth int) ([]*PostNode, int) {
+	if start >= len(pHashes) {
+		return []*PostNode{}, 0
+	}
+	var (
+		out  = make([]*PostNode, 0, b.pageSize)
+		more int
+	)
+	for i := start; i < len(pHashes); i++ {
+		if len(out) == b.pageSize {
+			more = len(pHashes) - i
+			break
+		}
+		if node := b.node(pHashes[i], depth); node != nil {
+			out = append(out, node)
+		}
+	}
+	return out, more
+}
+
+// node builds the node of a post, returns nil if the post is filtered.
+func (b *treeBuilder) node(pHash string, depth int) *PostNode {
+	post, ok := b.filter.apply(b.v.c.content[pHash])
+	if !ok || post == nil {
+		return nil
+	}
+	var (
+		replies = b.v.repliesOf(pHash)
+		out     = &PostNode{Post: b.v.view(pHash, post, b.perspective), ReplyCount: len(replies)}
+	)
+	if len(replies) == 0 {
+		return out
+	}
+	if _, isCollapsed := b.collapsed[pHash]; isCollapsed || depth >= b.maxDepth {
+		out.Collapsed = true
+		return out
+	}
+	out.Replies, out.MoreReplies = b.level(replies, 0, depth+1)
+	return out
+}
+
+// topLevelPosts obtains hashes of posts of a thread that are not replies to other posts.
+// Should only be used when viewer is locked.
+func (v *Viewer) topLevelPosts(tHash string) []string {
+	posts, ok := v.i.PostsOfThread[tHash]
+	if !ok {
+		return nil
+	}
+	var out []string
+	for _, pHash := range paginatedList(posts) {
+		body, ok := bodyOf(v.c.content[pHash])
+		if !ok {
+			continue
+		}
+		if _, hasParent := v.c.content[body.OfPost]; body.OfPost == "" || !hasParent {
+			out = append(out, pHash)
+		}
+	}
+	return out
+}
+
+// repliesOf obtains hashes of direct replies of a post.
+// Should only be used when viewer is locked.
+func (v *Viewer) repliesOf(pHash string) []string {
+	if replies, ok := v.i.PostsOfThread[pHash]; ok {
+		return paginatedList(replies)
+	}
+	return nil
+}
+
+// bodyOf obtains the body of compiled content, returns false if there is none.
+func bodyOf(rep *object.ContentRep) (*object.Body, bool) {
+	if rep == nil {
+		return nil, false
+	}
+	body, ok := rep.Body.(*object.Body)
+	return body, ok
+}
@@ -0,0 +1,142 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/skycoin/bbs/src/store/object"
+	"github.com/skycoin/skycoin/src/cipher"
+	"testing"
+	"time"
+)
+
+func addReply(t *testing.T, bi *BoardInstance, threadHash cipher.SHA256, ofPost string, postIndex int, userSeed []byte) string {
+	cpk, csk := cipher.GenerateDeterministicKeyPair(userSeed)
+	body := &object.Body{
+		Type:     object.V5PostType,
+		TS:       time.Now().UnixNano(),
+		OfBoard:  obtainBoardPubKey(t, bi).Hex(),
+		OfThread: threadHash.Hex(),
+		OfPost:   ofPost,
+		Name:     fmt.Sprintf("Post %d", postIndex),
+		Body:     fmt.Sprintf("A test post created of index %d.", postIndex),
+		Creator:  cpk.Hex(),
+	}
+	raw, _ := json.Marshal(body)
+	sig := cipher.SignHash(cipher.SumSHA256(raw), csk)
+	transport, e := object.NewTransport(raw, sig)
+	if e != nil {
+		t.Fatal("failed to generate transport:", e)
+	}
+	if _, e := bi.Submit(transport); e != nil {
+		t.Fatal("failed to create new post:", e)
+	}
+	return transport.Header.Hash
+}
+
+func TestViewer_GetThreadTree(t *testing.T) {
+	const (
+		boardSeed = "a"
+		userSeed  = "b"
+	)
+
+	bi, quit := initInstance(t, boardSeed)
+	defer quit()
+
+	tHash, _ := addThread(t, bi, 0, []byte(userSeed))
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+
+	// Thread:
+	//	p0
+	//	  r0
+	//	    rr0
+	//	  r1
+	//	p1
+	//	p2
+	p0 := addReply(t, bi, tHash, "", 0, []byte(userSeed))
+	p1 := addReply(t, bi, tHash, "", 1, []byte(userSeed))
+	p2 := addReply(t, bi, tHash, "", 2, []byte(userSeed))
+	r0 := addReply(t, bi, tHash, p0, 3, []byte(userSeed))
+	r1 := addReply(t, bi, tHash, p0, 4, []byte(userSeed))
+	rr0 := addReply(t, bi, tHash, r0, 5, []byte(userSeed))
+	if e := bi.PublishChanges(); e != nil {
+		t.Fatal("failed to publish changes:", e)
+	}
+
+	hashes := func(nodes []*PostNode) []string {
+		out := make([]string, len(nodes))
+		for i, node := range nodes {
+			out[i] = node.Post.Header.Hash
+		}
+		return out
+	}
+	check := func(t *testing.T, got []*PostNode, exp ...string) {
+		if h := hashes(got); fmt.Sprint(h) != fmt.Sprint(exp) {
+			t.Errorf("expected posts %v, got %v", exp, h)
+		}
+	}
+	get := func(t *testing.T, in *ThreadTreeIn) *ThreadTreeOut {
+		in.ThreadHash = tHash.Hex()
+		out, e := bi.Viewer().GetThreadTree(in)
+		if e != nil {
+			t.Fatal(e)
+		}
+		return out
+	}
+
+	t.Run("default", func(t *testing.T) {
+		out := get(t, &ThreadTreeIn{})
+		if out.TotalCount != 3 {
+			t.Errorf("expected total count 3, got %d", out.TotalCount)
+		}
+		check(t, out.Replies, p0, p1, p2)
+		check(t, out.Replies[0].Replies, r0, r1)
+		check(t, out.Replies[0].Replies[0].Replies, rr0)
+		if out.Replies[0].ReplyCount != 2 || out.Replies[1].ReplyCount != 0 {
+			t.Error("unexpected reply counts")
+		}
+	})
+
+	t.Run("max_depth", func(t *testing.T) {
+		out := get(t, &ThreadTreeIn{MaxDepth: 2})
+		node := out.Replies[0].Replies[0]
+		if !node.Collapsed || len(node.Replies) != 0 || node.ReplyCount != 1 {
+			t.Errorf("expected replies beyond max depth to be collapsed: %+v", node)
+		}
+	})
+
+	t.Run("collapsed", func(t *testing.T) {
+		out := get(t, &ThreadTreeIn{Collapsed: []string{p0}})
+		if node := out.Replies[0]; !node.Collapsed || len(node.Replies) != 0 {
+			t.Errorf("expected post to be collapsed: %+v", node)
+		}
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		out := get(t, &ThreadTreeIn{StartIndex: 1, PageSize: 1})
+		check(t, out.Replies, p1)
+
+		out = get(t, &ThreadTreeIn{PageSize: 1})
+		check(t, out.Replies, p0)
+		check(t, out.Replies[0].Replies, r0)
+		if out.Replies[0].MoreReplies != 1 {
+			t.Errorf("expected 1 more reply, got %d", out.Replies[0].MoreReplies)
+		}
+	})
+
+	t.Run("root", func(t *testing.T) {
+		out := get(t, &ThreadTreeIn{RootHash: r0})
+		if out.Root != r0 || out.TotalCount != 1 {
+			t.Errorf("unexpected root: %+v", out)
+		}
+		check(t, out.Replies, rr0)
+
+		if _, e := bi.Viewer().GetThreadTree(&ThreadTreeIn{
+			ThreadHash: tHash.Hex(),
+			RootHash:   tHash.Hex() + "0",
+		}); e == nil {
+			t.Error("expected error for unknown root")
+		}
+	})
+}
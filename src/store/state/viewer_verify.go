@@ -0,0 +1,130 @@
+package state
+
+import (
+	"github.com/skycoin/bbs/src/misc/boo"
+	"github.com/skycoin/bbs/src/store/object"
+	"github.com/skycoin/skycoin/src/cipher"
+	"sort"
+)
+
+// DefaultQuarantinePageSize is the page size used for quarantined content when none is specified.
+const DefaultQuarantinePageSize = 50
+
+// QuarantinedContent represents received content that failed verification,
+// and is hence excluded from views.
+type QuarantinedContent struct {
+	Hash    string             `json:"hash"`
+	Type    object.ContentType `json:"type"`
+	Creator string             `json:"creator"` // Claimed creator (unverified).
+	Seq     uint64             `json:"seq"`     // Root sequence in which the content is compiled from.
+	Reason  string             `json:"reason"`
+	Content *object.Content    `json:"content"`
+}
+
+// verifyContent checks that the header's hash is of the body, and that the
+// header's signature is of the body's creator.
+func verifyContent(c *object.Content, b *object.Body, h *object.ContentHeaderData) error {
+	creator, e := b.GetCreator()
+	if e != nil {
+		return e
+	}
+	if hash := cipher.SumSHA256(c.Body); hash.Hex() != h.Hash {
+		return boo.Newf(boo.InvalidRead, "header hash '%s' does not match body hash '%s'",
+			h.Hash, hash.Hex())
+	}
+	return h.Verify(creator)
+}
+
+// admit verifies received content when verification is enabled.
+// Content that fails verification is quarantined, in which case false is returned.
+// Posts of quarantined threads are also quarantined.
+// Should only be used when viewer is locked.
+func (v *Viewer) admit(seq uint64, c *object.Content, b *object.Body, h *object.ContentHeaderData) bool {
+	if !v.verify {
+		return true
+	}
+	var reason string
+	if _, ok := v.c.quarantine[b.OfThread]; ok && b.Type == object.V5PostType {
+		reason = "thread of post is quarantined"
+	} else if e := verifyContent(c, b, h); e != nil {
+		reason = e.Error()
+	} else {
+		return true
+	}
+	v.l.Printf("quarantined content '%s' of board '%s': %s", h.Hash, v.pk.Hex(), reason)
+	v.c.quarantine[h.Hash] = &QuarantinedContent{
+		Hash:    h.Hash,
+		Type:    b.Type,
+		Creator: b.Creator,
+		Seq:     seq,
+		Reason:  reason,
+		Content: c,
+	}
+	return false
+}
+
+// IsQuarantined determines whether content of hash is quarantined.
+func (v *Viewer) IsQuarantined(hash string) bool {
+	if v == nil {
+		return false
+	}
+	defer v.rLock()()
+	_, ok := v.c.quarantine[hash]
+	return ok
+}
+
+// QuarantineIn represents the input required to obtain quarantined content.
+type QuarantineIn struct {
+	StartIndex int
+	PageSize   int
+}
+
+// QuarantineOut represents the quarantined content of a board.
+type QuarantineOut struct {
+	BoardPubKey string                `json:"board_public_key"`
+	Verified    bool                  `json:"verified"` // Whether content of board is verified when compiled.
+	TotalCount  int                   `json:"total_count"`
+	StartIndex  int                   `json:"start_index"`
+	Content     []*QuarantinedContent `json:"content"` // Ordered by root sequence, then hash.
+}
+
+// GetQuarantine obtains content that failed verification.
+func (v *Viewer) GetQuarantine(in *QuarantineIn) (*QuarantineOut, error) {
+	if v == nil {
+		return nil, ErrViewerNotInitialized
+	}
+	if in.StartIndex < 0 {
+		return nil, boo.Newf(boo.InvalidInput, "invalid start index %d", in.StartIndex)
+	}
+	pageSize := in.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultQuarantinePageSize
+	}
+	defer v.rLock()()
+
+	all := make([]*QuarantinedContent, 0, len(v.c.quarantine))
+	for _, qc := range v.c.quarantine {
+		all = append(all, qc)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Seq != all[j].Seq {
+			return all[i].Seq < all[j].Seq
+		}
+		return all[i].Hash < all[j].Hash
+	})
+	out := &QuarantineOut{
+		BoardPubKey: v.pk.Hex(),
+		Verified:    v.verify,
+		TotalCount:  len(all),
+		StartIndex:  in.StartIndex,
+		Content:     make([]*QuarantinedContent, 0),
+	}
+	if in.StartIndex < len(all) {
+		end := in.StartIndex + pageSize
+		if end > len(all) {
+			end = len(all)
+		}
+		out.Content = all[in.StartIndex:end]
+	}
+	return out, nil
+}
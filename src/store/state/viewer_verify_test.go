@@ -0,0 +1,86 @@
+package state
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/skycoin/bbs/src/store/object"
+	"github.com/skycoin/skycoin/src/cipher"
+	"testing"
+	"time"
+)
+
+func newThreadContent(t *testing.T, bi *BoardInstance, name string, creatorSeed, signerSeed []byte) *object.Content {
+	cpk, _ := cipher.GenerateDeterministicKeyPair(creatorSeed)
+	_, ssk := cipher.GenerateDeterministicKeyPair(signerSeed)
+	raw, _ := json.Marshal(&object.Body{
+		Type:    object.V5ThreadType,
+		TS:      time.Now().UnixNano(),
+		OfBoard: obtainBoardPubKey(t, bi).Hex(),
+		Name:    name,
+		Body:    "A test thread.",
+		Creator: cpk.Hex(),
+	})
+	header, _ := json.Marshal(&object.ContentHeaderData{
+		Hash: cipher.SumSHA256(raw).Hex(),
+		Sig:  cipher.SignHash(cipher.SumSHA256(raw), ssk).Hex(),
+	})
+	return &object.Content{Header: header, Body: raw}
+}
+
+func TestViewer_GetQuarantine(t *testing.T) {
+	const (
+		boardSeed  = "a"
+		userSeed   = "b"
+		forgerSeed = "c"
+	)
+
+	bi, quit := initInstance(t, boardSeed)
+	defer quit()
+
+	valid := newThreadContent(t, bi, "Valid", []byte(userSeed), []byte(userSeed))
+	wrongSigner := newThreadContent(t, bi, "Wrong Signer", []byte(userSeed), []byte(forgerSeed))
+	tampered := newThreadContent(t, bi, "Tampered", []byte(userSeed), []byte(userSeed))
+	tampered.Body = bytes.Replace(tampered.Body, []byte("A test"), []byte("A forged"), 1)
+
+	v := bi.Viewer()
+	admit := func(c *object.Content) bool {
+		defer v.lock()()
+		return v.admit(1, c, c.GetBody(), c.GetHeader())
+	}
+
+	t.Run("unverified", func(t *testing.T) {
+		if !admit(wrongSigner) {
+			t.Error("expected content of local board to be admitted without verification")
+		}
+	})
+
+	unlock := v.lock()
+	v.verify = true
+	unlock()
+
+	if !admit(valid) {
+		t.Error("expected valid content to be admitted")
+	}
+	if admit(wrongSigner) {
+		t.Error("expected content signed by another key to be quarantined")
+	}
+	if admit(tampered) {
+		t.Error("expected tampered content to be quarantined")
+	}
+
+	out, e := v.GetQuarantine(&QuarantineIn{})
+	if e != nil {
+		t.Fatal(e)
+	}
+	if !out.Verified || out.TotalCount != 2 || len(out.Content) != 2 {
+		t.Fatalf("expected 2 quarantined content, got %d", out.TotalCount)
+	}
+	for _, c := range []*object.Content{wrongSigner, tampered} {
+		if hash := c.GetHeader().Hash; !v.IsQuarantined(hash) {
+			t.Errorf("expected content '%s' to be quarantined", hash)
+		}
+	}
+	if v.IsQuarantined(valid.GetHeader().Hash) {
+		t.Error("expected valid content to not be quarantined")
+	}
+}